@@ -1,6 +1,87 @@
 // Code generated by tygo. DO NOT EDIT.
 import {APIResponse} from "./base"
 
+//////////
+// source: action_log.go
+
+export interface ActionLogWire {
+  id: string;
+  guild_id: string;
+  channel_id: string;
+  message_id: null | string;
+  source_id: string;
+  user_id: string;
+  actions: Record<string, any> | null;
+  success: boolean;
+  error: null | string;
+  created_at: string /* RFC3339 */;
+  expires_at: string /* RFC3339 */;
+}
+export type ActionLogListResponseWire = APIResponse<ActionLogWire[]>;
+
+//////////
+// source: action_set.go
+
+export interface ActionSetWire {
+  id: string;
+  guild_id: string;
+  name: string;
+  description: null | string;
+  actions: Record<string, any> | null;
+  created_at: string /* RFC3339 */;
+  updated_at: string /* RFC3339 */;
+}
+export type ActionSetListResponseWire = APIResponse<ActionSetWire[]>;
+export type ActionSetGetResponseWire = APIResponse<ActionSetWire>;
+export interface ActionSetCreateRequestWire {
+  name: string;
+  description: null | string;
+  actions: Record<string, any> | null;
+}
+export type ActionSetCreateResponseWire = APIResponse<ActionSetWire>;
+export interface ActionSetUpdateRequestWire {
+  name: string;
+  description: null | string;
+  actions: Record<string, any> | null;
+}
+export type ActionSetUpdateResponseWire = APIResponse<ActionSetWire>;
+export type ActionSetDeleteResponseWire = APIResponse<{
+  }>;
+
+//////////
+// source: action_simulation.go
+
+export interface ActionSimulateRequestWire {
+  channel_id: string;
+  member: SimulatedMemberWire;
+  action_set: Record<string, any> | null;
+}
+/**
+ * SimulatedMemberWire is the member that uses the component, it defaults to the current user.
+ */
+export interface SimulatedMemberWire {
+  user_id: null | string;
+  roles: string[];
+  permissions: string;
+  locale: string;
+}
+export interface ActionSimulationEventWire {
+  type: string;
+  channel_id: null | string;
+  message_id: null | string;
+  user_id: null | string;
+  role_id: null | string;
+  key: null | string;
+  value: null | string;
+  method: null | string;
+  url: null | string;
+  data: Record<string, any> | null;
+}
+export interface ActionSimulateResponseDataWire {
+  events: ActionSimulationEventWire[];
+}
+export type ActionSimulateResponseWire = APIResponse<ActionSimulateResponseDataWire>;
+
 //////////
 // source: assistant.go
 
@@ -103,6 +184,24 @@ export type CustomCommandDeleteResponseWire = APIResponse<{
 export type CustomCommandsDeployResponseWire = APIResponse<{
   }>;
 
+//////////
+// source: delayed_action.go
+
+export interface DelayedActionWire {
+  id: string;
+  guild_id: string;
+  channel_id: string;
+  message_id: null | string;
+  source_id: string;
+  user_id: string;
+  actions: Record<string, any> | null;
+  execute_at: string /* RFC3339 */;
+  created_at: string /* RFC3339 */;
+}
+export type DelayedActionListResponseWire = APIResponse<DelayedActionWire[]>;
+export type DelayedActionDeleteResponseWire = APIResponse<{
+  }>;
+
 //////////
 // source: embeds_links.go
 
@@ -134,6 +233,26 @@ export interface EmbedLinkOEmbedResponseWire {
   provider_url?: string;
 }
 
+//////////
+// source: form_submission.go
+
+export interface FormSubmissionFieldWire {
+  name: string;
+  label: string;
+  value: string;
+}
+export interface FormSubmissionWire {
+  id: string;
+  guild_id: string;
+  channel_id: string;
+  message_id: null | string;
+  user_id: string;
+  title: string;
+  fields: FormSubmissionFieldWire[];
+  created_at: string /* RFC3339 */;
+}
+export type FormSubmissionListResponseWire = APIResponse<FormSubmissionWire[]>;
+
 //////////
 // source: guild.go
 
@@ -253,6 +372,7 @@ export interface MessageSendToChannelRequestWire {
   channel_id: string;
   thread_name: null | string;
   message_id: null | string;
+  saved_message_id: null | string;
   data: Record<string, any> | null;
   attachments: (MessageAttachmentWire | undefined)[];
 }
@@ -300,6 +420,11 @@ export interface GetPremiumPlanFeaturesResponseDataWire {
   periodic_scheduled_messages: boolean;
   max_template_ops: number /* int */;
   max_kv_keys: number /* int */;
+  http_request_actions: boolean;
+  action_log_retention_days: number /* int */;
+  max_action_sets: number /* int */;
+  max_template_snippet_depth: number /* int */;
+  max_template_snippets_size: number /* int */;
 }
 export type GetPremiumPlanFeaturesResponseWire = APIResponse<GetPremiumPlanFeaturesResponseDataWire>;
 export interface PremiumEntitlementWire {
@@ -399,6 +524,114 @@ export interface SharedMessageCreateRequestWire {
 export type SharedMessageCreateResponseWire = APIResponse<SharedMessageWire>;
 export type SharedMessageGetResponseWire = APIResponse<SharedMessageWire>;
 
+//////////
+// source: signing_secret.go
+
+export interface SigningSecretWire {
+  guild_id: string;
+  secret: string;
+  created_at: string /* RFC3339 */;
+}
+export type SigningSecretGetResponseWire = APIResponse<SigningSecretWire>;
+export type SigningSecretRegenerateResponseWire = APIResponse<SigningSecretWire>;
+
+//////////
+// source: template.go
+
+export interface TemplateValidateRequestWire {
+  guild_id: string;
+  data: Record<string, any> | null;
+}
+/**
+ * TemplateIssueWire is a problem in one of the templates of the message.
+ * The line and column are 1-based and 0 if the position is unknown.
+ */
+export interface TemplateIssueWire {
+  path: string;
+  message: string;
+  line: number /* int */;
+  column: number /* int */;
+}
+export interface TemplateValidateResponseDataWire {
+  errors: TemplateIssueWire[];
+  warnings: TemplateIssueWire[];
+}
+export type TemplateValidateResponseWire = APIResponse<TemplateValidateResponseDataWire>;
+export interface TemplateRenderRequestWire {
+  channel_id: string;
+  member: SimulatedMemberWire;
+  command?: TemplateRenderCommandWire;
+  /**
+   * KV overrides the values of keys for the preview, the stored entries aren't changed
+   */
+  kv: { [key: string]: string};
+  /**
+   * SavedMessageID renders a message that has been saved for the server of the channel, the data is ignored then
+   */
+  saved_message_id: null | string;
+  data: Record<string, any> | null;
+}
+/**
+ * TemplateRenderCommandWire is the command that the message is rendered for, it's available as .Interaction.Command.
+ */
+export interface TemplateRenderCommandWire {
+  name: string;
+  options: TemplateRenderCommandOptionWire[];
+}
+export interface TemplateRenderCommandOptionWire {
+  name: string;
+  type: number /* int */;
+  value: string;
+}
+export interface TemplateRenderResponseDataWire {
+  data: Record<string, any> | null;
+}
+export type TemplateRenderResponseWire = APIResponse<TemplateRenderResponseDataWire>;
+
+//////////
+// source: template_snippet.go
+
+export interface TemplateSnippetWire {
+  id: string;
+  guild_id: string;
+  name: string;
+  description: null | string;
+  content: string;
+  created_at: string /* RFC3339 */;
+  updated_at: string /* RFC3339 */;
+}
+export type TemplateSnippetListResponseWire = APIResponse<TemplateSnippetWire[]>;
+export type TemplateSnippetGetResponseWire = APIResponse<TemplateSnippetWire>;
+export interface TemplateSnippetCreateRequestWire {
+  name: string;
+  description: null | string;
+  content: string;
+}
+export type TemplateSnippetCreateResponseWire = APIResponse<TemplateSnippetWire>;
+export interface TemplateSnippetUpdateRequestWire {
+  name: string;
+  description: null | string;
+  content: string;
+}
+export type TemplateSnippetUpdateResponseWire = APIResponse<TemplateSnippetWire>;
+export type TemplateSnippetDeleteResponseWire = APIResponse<{
+  }>;
+
+//////////
+// source: temporary_role.go
+
+export interface TemporaryRoleWire {
+  id: string;
+  guild_id: string;
+  user_id: string;
+  role_id: string;
+  expires_at: string /* RFC3339 */;
+  created_at: string /* RFC3339 */;
+}
+export type TemporaryRoleListResponseWire = APIResponse<TemporaryRoleWire[]>;
+export type TemporaryRoleRevokeResponseWire = APIResponse<{
+  }>;
+
 //////////
 // source: user.go
 
@@ -81,6 +81,7 @@ export default function SendMenuChannel() {
         channel_id: selectedChannnelId,
         thread_name: selectedChannel?.type === 15 ? threadName : null,
         message_id: edit ? messageId : null,
+        saved_message_id: null,
         data: useCurrentMessageStore.getState(),
         attachments: useCurrentAttachmentsStore.getState().attachments,
       },
@@ -1,7 +1,9 @@
 package actions
 
 import (
+	"fmt"
 	"slices"
+	"time"
 
 	"github.com/merlinfuchs/discordgo"
 )
@@ -15,6 +17,37 @@ type MessageWithActions struct {
 	AllowedMentions *discordgo.MessageAllowedMentions `json:"allowed_mentions,omitempty"`
 	Components      []ActionRowWithActions            `json:"components,omitempty"`
 	Actions         map[string]ActionSet              `json:"actions,omitempty"`
+	Reactions       []ReactionWithActions             `json:"reactions,omitempty"`
+	// Pages turns the message into a book of other saved messages that can be browsed with navigation buttons
+	Pages []string `json:"pages,omitempty"`
+}
+
+// MaxBookPages is the maximum number of saved messages that a book can contain.
+const MaxBookPages = 25
+
+// MaxMessageReactions is the maximum number of different reactions that Discord allows on a single message.
+const MaxMessageReactions = 20
+
+// ReactionWithActions executes the action set when a member reacts to the message with the emoji.
+// When the reaction is removed again, the role actions of the action set are reversed.
+type ReactionWithActions struct {
+	Emoji       *discordgo.ComponentEmoji `json:"emoji"`
+	ActionSetID string                    `json:"action_set_id"`
+}
+
+// APIName returns the emoji in the format that is expected by the Discord API when adding reactions.
+func (r *ReactionWithActions) APIName() string {
+	emoji := discordgo.Emoji{ID: r.Emoji.ID, Name: r.Emoji.Name}
+	return emoji.APIName()
+}
+
+// ReactionEmojiKey identifies the emoji of a reaction.
+// Custom emojis are identified by their id because they can be renamed.
+func ReactionEmojiKey(emojiID string, emojiName string) string {
+	if emojiID != "" {
+		return emojiID
+	}
+	return emojiName
 }
 
 type ActionRowWithActions struct {
@@ -37,6 +70,20 @@ type ComponentWithActions struct {
 	MinValues   *int                               `json:"min_values"`
 	MaxValues   int                                `json:"max_values"`
 	Options     []ComponentSelectOptionWithActions `json:"options"`
+
+	// Channel Select Menu
+	ChannelTypes []discordgo.ChannelType `json:"channel_types,omitempty"`
+}
+
+// IsAutoPopulatedSelectMenu returns whether the component is a user, role, mentionable or channel select menu.
+// These menus don't have options, the action set of the component is executed with the picked values instead.
+func (c *ComponentWithActions) IsAutoPopulatedSelectMenu() bool {
+	switch c.Type {
+	case discordgo.UserSelectMenuComponent, discordgo.RoleSelectMenuComponent,
+		discordgo.MentionableSelectMenuComponent, discordgo.ChannelSelectMenuComponent:
+		return true
+	}
+	return false
 }
 
 type ComponentSelectOptionWithActions struct {
@@ -45,6 +92,8 @@ type ComponentSelectOptionWithActions struct {
 	Emoji       *discordgo.ComponentEmoji `json:"emoji"`
 	Default     bool                      `json:"default"`
 	ActionSetID string                    `json:"action_set_id"`
+	// DeselectActionSetID is executed when the option isn't picked but the member currently holds the roles of the option
+	DeselectActionSetID string `json:"deselect_action_set_id,omitempty"`
 }
 
 type ActionType int
@@ -60,8 +109,43 @@ const (
 	ActionTypeTextEdit             ActionType = 8
 	ActionTypeSavedMessageEdit     ActionType = 9
 	ActionTypePermissionCheck      ActionType = 10
+	ActionTypeModalForm            ActionType = 11
+	ActionTypeConditional          ActionType = 12
+	ActionTypeWait                 ActionType = 13
+	ActionTypeSavedMessageChannel  ActionType = 14
+	ActionTypeCreateThread         ActionType = 15
+	ActionTypeCloseThread          ActionType = 16
+	ActionTypeHTTPRequest          ActionType = 17
+	ActionTypePollVote             ActionType = 18
+	ActionTypeSetNickname          ActionType = 19
+	ActionTypeTimeout              ActionType = 20
+	ActionTypeKick                 ActionType = 21
+	ActionTypeDeleteMessage        ActionType = 22
+	ActionTypeDisableComponents    ActionType = 23
+	ActionTypeRandomChoice         ActionType = 24
 )
 
+// MaxFormFields is the maximum number of text inputs Discord allows in a single modal.
+const MaxFormFields = 5
+
+// MaxRequestFields is the maximum number of fields in the payload of a http request action.
+const MaxRequestFields = 25
+
+// MaxChoices is the maximum number of choices that a random choice action can pick from.
+const MaxChoices = 25
+
+// MaxWaitDuration is the maximum time that a wait action can delay the following actions.
+const MaxWaitDuration = 30 * 24 * time.Hour
+
+// MaxTimeoutDuration is the maximum time that Discord allows members to be timed out for.
+const MaxTimeoutDuration = 28 * 24 * time.Hour
+
+// MaxRoleGroupSize is the maximum number of roles in the role group of a role action.
+const MaxRoleGroupSize = 25
+
+// MaxTemporaryRoleDuration is the maximum time that a temporary role can be assigned for.
+const MaxTemporaryRoleDuration = 365 * 24 * time.Hour
+
 type Action struct {
 	Type                   ActionType `json:"type"`
 	TargetID               string     `json:"target_id"`
@@ -70,10 +154,192 @@ type Action struct {
 	DisableDefaultResponse bool       `json:"disable_default_response"`
 	Permissions            string     `json:"permissions"`
 	RoleIDs                []string   `json:"role_ids"`
+	ChannelID              string     `json:"channel_id,omitempty"`
+	// TargetSelected makes the action use the values that have been picked in a select menu instead of the target
+	TargetSelected bool `json:"target_selected,omitempty"`
+	// RoleGroup are roles that are mutually exclusive with the roles that are added by add and toggle role actions.
+	// The member loses the other roles of the group when they get one of them.
+	RoleGroup []string `json:"role_group,omitempty"`
+	// OnlyClicked makes disable components actions only disable the component that has been used
+	OnlyClicked bool `json:"only_clicked,omitempty"`
+
+	// Modal Form
+	Form *ActionForm `json:"form,omitempty"`
+
+	// Conditional
+	Condition *ActionCondition `json:"condition,omitempty"`
+
+	// Duration is the delay of wait actions, the lifetime of roles that are added by role actions and the length of timeouts
+	Duration int `json:"duration,omitempty"` // in seconds
+
+	// HTTP Request
+	Request *ActionRequest `json:"request,omitempty"`
+
+	// Poll Vote
+	Poll *ActionPoll `json:"poll,omitempty"`
+
+	// Random Choice
+	Choices []ActionChoice `json:"choices,omitempty"`
+}
+
+// NestedActionSets returns the action sets that are contained in the action itself.
+func (a *Action) NestedActionSets() []*ActionSet {
+	switch a.Type {
+	case ActionTypeModalForm:
+		if a.Form != nil {
+			return []*ActionSet{&a.Form.ActionSet}
+		}
+	case ActionTypeConditional:
+		if a.Condition != nil {
+			return []*ActionSet{&a.Condition.Then, &a.Condition.Else}
+		}
+	case ActionTypeRandomChoice:
+		res := make([]*ActionSet, len(a.Choices))
+		for i := range a.Choices {
+			res[i] = &a.Choices[i].ActionSet
+		}
+		return res
+	}
+
+	return nil
+}
+
+// RespondsToInteraction returns whether the action responds to the interaction or edits the message of the component.
+// These actions can't be executed after a wait, because the interaction has expired by then.
+func (a *Action) RespondsToInteraction() bool {
+	switch a.Type {
+	case ActionTypeTextResponse, ActionTypeTextEdit, ActionTypeSavedMessageResponse, ActionTypeSavedMessageEdit, ActionTypeModalForm:
+		return true
+	case ActionTypePollVote:
+		// The message is only edited if a saved message has been configured for the vote
+		return a.TargetID != ""
+	}
+
+	return false
+}
+
+// DelaysExecution returns whether the actions after this action are executed later, either by the action itself or one of its nested actions.
+func (a *Action) DelaysExecution() bool {
+	if a.Type == ActionTypeWait {
+		return true
+	}
+
+	for _, actionSet := range a.NestedActionSets() {
+		for i := range actionSet.Actions {
+			if actionSet.Actions[i].DelaysExecution() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type ActionForm struct {
+	Title  string            `json:"title"`
+	Fields []ActionFormField `json:"fields"`
+	// ActionSet is executed when the form is submitted, the actions after the form are executed afterwards
+	ActionSet ActionSet `json:"action_set"`
+}
+
+// Validate checks that the form can be shown as a modal.
+// The names of the fields are used as the custom ids of the text inputs, so they must be set and unique.
+func (f *ActionForm) Validate() error {
+	if len(f.Fields) == 0 {
+		return fmt.Errorf("Forms must have at least one field")
+	}
+
+	if len(f.Fields) > MaxFormFields {
+		return fmt.Errorf("Forms can't have more than %d fields", MaxFormFields)
+	}
+
+	names := make(map[string]bool, len(f.Fields))
+	for _, field := range f.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("All fields of forms must have a name")
+		}
+
+		if names[field.Name] {
+			return fmt.Errorf("The field name %s is used more than once in the form", field.Name)
+		}
+		names[field.Name] = true
+	}
+
+	return nil
+}
+
+type ActionFormField struct {
+	Name        string                   `json:"name"`
+	Label       string                   `json:"label"`
+	Style       discordgo.TextInputStyle `json:"style"`
+	Placeholder string                   `json:"placeholder"`
+	Value       string                   `json:"value"`
+	Required    bool                     `json:"required"`
+	MinLength   int                      `json:"min_length"`
+	MaxLength   int                      `json:"max_length"`
+}
+
+type ActionCondition struct {
+	// Expression is a template that is considered true if it doesn't render to an empty string, "false" or "0"
+	Expression string `json:"expression"`
+	// Then is executed when the expression is true
+	Then ActionSet `json:"then"`
+	// Else is executed when the expression is false
+	Else ActionSet `json:"else"`
+}
+
+type ActionChoice struct {
+	// Weight is the chance of the choice to be picked relative to the weights of the other choices
+	Weight int `json:"weight"`
+	// ActionSet is executed when the choice is picked
+	ActionSet ActionSet `json:"action_set"`
+}
+
+type ActionRequest struct {
+	URL string `json:"url"`
+	// Fields are templates that are added to the payload of the request
+	Fields []ActionRequestField `json:"fields"`
+	// ExposeResponse makes the response available to the following actions as .Response
+	ExposeResponse bool `json:"expose_response"`
+}
+
+type ActionRequestField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ActionPoll struct {
+	// Option is the option that the user votes for, each user has a single vote per message
+	Option string `json:"option"`
+	// ClosesAt is the time after which no more votes are accepted and the results are posted
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	// ResultsMessageID is the saved message that is sent to the channel when the poll is closed
+	ResultsMessageID string `json:"results_message_id,omitempty"`
+}
+
+type FormSubmissionField struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Value string `json:"value"`
 }
 
 type ActionSet struct {
 	Actions []Action `json:"actions"`
+	// LibraryID references an action set of the guild's library that is executed instead of the actions above
+	LibraryID string `json:"library_id,omitempty"`
+
+	// Cooldown is the time in seconds that a user has to wait before using the action set again
+	Cooldown int `json:"cooldown,omitempty"`
+	// MaxUsesPerUser is the number of times that each user can use the action set
+	MaxUsesPerUser int `json:"max_uses_per_user,omitempty"`
+	// MaxUses is the number of times that the action set can be used across all users
+	MaxUses int `json:"max_uses,omitempty"`
+	// LimitResponse is sent to users that have been blocked by one of the limits above
+	LimitResponse string `json:"limit_response,omitempty"`
+}
+
+// HasLimits returns whether the usage of the action set has to be tracked.
+func (s *ActionSet) HasLimits() bool {
+	return s.Cooldown > 0 || s.MaxUsesPerUser > 0 || s.MaxUses > 0
 }
 
 type ActionDerivedPermissions struct {
@@ -92,6 +358,20 @@ func (a *ActionDerivedPermissions) HasGuildPermission(permission int64) bool {
 	return a.GuildIsOwner || (a.GuildPermissions&discordgo.PermissionAdministrator) != 0 || (a.GuildPermissions&permission) != 0
 }
 
+// CanManageMember returns whether all roles of the member are below the highest role of the user.
+func (a *ActionDerivedPermissions) CanManageMember(member *discordgo.Member) bool {
+	if a.GuildIsOwner {
+		return true
+	}
+
+	for _, roleID := range member.Roles {
+		if !slices.Contains(a.AllowedRoleIDs, roleID) {
+			return false
+		}
+	}
+	return true
+}
+
 func (a *ActionDerivedPermissions) CanManageRole(roleID string) bool {
 	if a.GuildIsOwner {
 		return true
@@ -99,3 +379,44 @@ func (a *ActionDerivedPermissions) CanManageRole(roleID string) bool {
 
 	return a.HasGuildPermission(discordgo.PermissionManageRoles) && slices.Contains(a.AllowedRoleIDs, roleID)
 }
+
+// Intersect returns the permissions that both a and b have.
+// The user ID of a is kept, so permissions that are derived later on are derived for that user.
+func (a *ActionDerivedPermissions) Intersect(b ActionDerivedPermissions) ActionDerivedPermissions {
+	res := ActionDerivedPermissions{
+		UserID:             a.UserID,
+		GuildIsOwner:       a.GuildIsOwner && b.GuildIsOwner,
+		GuildPermissions:   a.effectiveGuildPermissions() & b.effectiveGuildPermissions(),
+		ChannelPermissions: a.effectiveChannelPermissions() & b.effectiveChannelPermissions(),
+	}
+
+	switch {
+	case a.GuildIsOwner:
+		res.AllowedRoleIDs = b.AllowedRoleIDs
+	case b.GuildIsOwner:
+		res.AllowedRoleIDs = a.AllowedRoleIDs
+	default:
+		for _, roleID := range a.AllowedRoleIDs {
+			if slices.Contains(b.AllowedRoleIDs, roleID) {
+				res.AllowedRoleIDs = append(res.AllowedRoleIDs, roleID)
+			}
+		}
+	}
+
+	return res
+}
+
+func (a *ActionDerivedPermissions) effectiveGuildPermissions() int64 {
+	if a.GuildIsOwner || (a.GuildPermissions&discordgo.PermissionAdministrator) != 0 {
+		// All bits are set, so newer permissions that aren't part of discordgo.PermissionAll are included
+		return ^int64(0)
+	}
+	return a.GuildPermissions
+}
+
+func (a *ActionDerivedPermissions) effectiveChannelPermissions() int64 {
+	if a.GuildIsOwner || (a.GuildPermissions&discordgo.PermissionAdministrator) != 0 {
+		return ^int64(0)
+	}
+	return a.ChannelPermissions
+}
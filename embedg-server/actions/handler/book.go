@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+// bookActionSetIDPrefix is the prefix of the navigation buttons of books.
+// The full id is book:<book id>:<page>:<position>, so the navigation works without storing any state.
+const bookActionSetIDPrefix = "book:"
+
+// bookActionSetID is the id of the empty action set that is stored for messages that show a book.
+// It keeps the permissions of the creator around, so the actions of the other pages can be created when navigating.
+const bookActionSetID = "book"
+
+// maxActionRows is the maximum number of action rows that Discord allows on a single message.
+const maxActionRows = 5
+
+// openBook returns the first page of the saved message if it's a book, otherwise the data is returned unchanged.
+func (m *ActionHandler) openBook(e *actionExecution, bookID string, data *actions.MessageWithActions) (*actions.MessageWithActions, error) {
+	return m.OpenBook(context.TODO(), e.interaction.GuildID, bookID, data)
+}
+
+// OpenBook returns the first page of the saved message if it's a book, otherwise the data is returned unchanged.
+// It has to be used by everything that sends saved messages, so books are shown with their navigation.
+func (m *ActionHandler) OpenBook(ctx context.Context, guildID string, bookID string, data *actions.MessageWithActions) (*actions.MessageWithActions, error) {
+	if len(data.Pages) == 0 {
+		return data, nil
+	}
+
+	return m.getBookPage(ctx, guildID, bookID, data.Pages, 0)
+}
+
+// getBookPage returns the saved message of the page with the navigation buttons of the book added to its components.
+func (m *ActionHandler) getBookPage(ctx context.Context, guildID string, bookID string, pages []string, page int) (*actions.MessageWithActions, error) {
+	if len(pages) > actions.MaxBookPages {
+		pages = pages[:actions.MaxBookPages]
+	}
+	if page >= len(pages) {
+		page = len(pages) - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	msg, err := m.pg.Q.GetSavedMessageForGuild(ctx, pgmodel.GetSavedMessageForGuildParams{
+		GuildID: sql.NullString{Valid: true, String: guildID},
+		ID:      pages[page],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := &actions.MessageWithActions{}
+	err = json.Unmarshal(msg.Data, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Books can't be nested, a page is always shown as a regular message
+	data.Pages = nil
+
+	if len(data.Components) >= maxActionRows {
+		data.Components = data.Components[:maxActionRows-1]
+	}
+	data.Components = append(data.Components, bookNavigation(bookID, page, len(pages)))
+
+	if data.Actions == nil {
+		data.Actions = make(map[string]actions.ActionSet, 1)
+	}
+	data.Actions[bookActionSetID] = actions.ActionSet{}
+
+	return data, nil
+}
+
+// bookNavigation returns the previous, page indicator and next buttons of a book.
+func bookNavigation(bookID string, page int, pageCount int) actions.ActionRowWithActions {
+	button := func(label string, targetPage int, position string, disabled bool) actions.ComponentWithActions {
+		return actions.ComponentWithActions{
+			Type:        discordgo.ButtonComponent,
+			Style:       discordgo.SecondaryButton,
+			Label:       label,
+			Disabled:    disabled,
+			ActionSetID: fmt.Sprintf("%s%s:%d:%s", bookActionSetIDPrefix, bookID, targetPage, position),
+		}
+	}
+
+	return actions.ActionRowWithActions{
+		Components: []actions.ComponentWithActions{
+			button("Previous", page-1, "prev", page == 0),
+			button(fmt.Sprintf("%d / %d", page+1, pageCount), page, "page", true),
+			button("Next", page+1, "next", page >= pageCount-1),
+		},
+	}
+}
+
+// handleBookInteraction edits the message in place to show the page of the book that has been navigated to.
+func (m *ActionHandler) handleBookInteraction(s *discordgo.Session, i Interaction) error {
+	interaction := i.Interaction()
+	data := interaction.MessageComponentData()
+
+	parts := strings.Split(strings.TrimPrefix(data.CustomID, "action:"+bookActionSetIDPrefix), ":")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	bookID := parts[0]
+	page, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil
+	}
+
+	col, err := m.pg.Q.GetMessageActionSet(context.TODO(), pgmodel.GetMessageActionSetParams{
+		MessageID: interaction.Message.ID,
+		SetID:     bookActionSetID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		log.Error().Err(err).Msg("Failed to get message action set")
+		return err
+	}
+
+	_, e, err := m.newActionExecution(s, i, bookActionSetID, col.Actions, col.DerivedPermissions)
+	if err != nil {
+		return err
+	}
+
+	msg, err := m.pg.Q.GetSavedMessageForGuild(context.TODO(), pgmodel.GetSavedMessageForGuildParams{
+		GuildID: sql.NullString{Valid: true, String: interaction.GuildID},
+		ID:      bookID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(e, "This book doesn't exist anymore.")
+			return nil
+		}
+		return err
+	}
+
+	book := &actions.MessageWithActions{}
+	err = json.Unmarshal(msg.Data, book)
+	if err != nil {
+		return err
+	}
+
+	if len(book.Pages) == 0 {
+		respondError(e, "This book doesn't have any pages anymore.")
+		return nil
+	}
+
+	pageData, err := m.getBookPage(context.TODO(), interaction.GuildID, bookID, book.Pages, page)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(e, "This page of the book doesn't exist anymore.")
+			return nil
+		}
+		return err
+	}
+
+	e.variables.FillMessage(pageData)
+	if !executeTemplateMessage(e, pageData) {
+		return nil
+	}
+
+	var components []discordgo.MessageComponent
+	if !e.legacyPermissions {
+		components, err = m.parser.ParseMessageComponents(pageData.Components)
+		if err != nil {
+			return fmt.Errorf("Invalid actions: %w", err)
+		}
+	}
+
+	i.Respond(&discordgo.InteractionResponseData{
+		Content:    pageData.Content,
+		Embeds:     pageData.Embeds,
+		Components: components,
+	}, discordgo.InteractionResponseUpdateMessage)
+
+	if !e.legacyPermissions {
+		ephemeral := interaction.Message.Flags&discordgo.MessageFlagsEphemeral != 0
+		err = m.parser.CreateActionsForMessage(context.TODO(), pageData.Actions, e.derivedPerms, interaction.Message.ID, ephemeral)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create actions for message")
+			return err
+		}
+
+		err = m.parser.CreatePollForMessage(context.TODO(), pageData.Actions, interaction.GuildID, interaction.ChannelID, interaction.Message.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create poll for message")
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/util"
+	"github.com/rs/zerolog/log"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// scheduleDelayedActions stores the actions so they are executed by the background task once the delay has passed.
+func (m *ActionHandler) scheduleDelayedActions(e *actionExecution, actionList []actions.Action, delay time.Duration) error {
+	if m.recorder != nil {
+		m.recorder.recordDelayedActions(actionList, delay)
+		return nil
+	}
+
+	rawActions, err := json.Marshal(actions.ActionSet{Actions: actionList})
+	if err != nil {
+		return err
+	}
+
+	var rawDerivedPerms pqtype.NullRawMessage
+	if !e.legacyPermissions {
+		raw, err := json.Marshal(e.derivedPerms)
+		if err != nil {
+			return err
+		}
+		rawDerivedPerms = pqtype.NullRawMessage{RawMessage: raw, Valid: true}
+	}
+
+	// The token is only valid for 15 minutes, there is no point in storing it
+	interaction := *e.interaction
+	interaction.Token = ""
+
+	rawInteraction, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+
+	var messageID sql.NullString
+	if interaction.Message != nil {
+		messageID = sql.NullString{String: interaction.Message.ID, Valid: true}
+	}
+
+	_, err = m.pg.Q.InsertDelayedAction(context.TODO(), pgmodel.InsertDelayedActionParams{
+		ID:                 util.UniqueID(),
+		GuildID:            interaction.GuildID,
+		ChannelID:          interaction.ChannelID,
+		MessageID:          messageID,
+		SourceID:           e.sourceID,
+		UserID:             interactionUserID(&interaction),
+		Actions:            rawActions,
+		DerivedPermissions: rawDerivedPerms,
+		Interaction:        rawInteraction,
+		ExecuteAt:          time.Now().UTC().Add(delay),
+		CreatedAt:          time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to insert delayed action")
+		return err
+	}
+
+	return nil
+}
+
+// delayedActionsBatchSize is the maximum number of delayed actions that are executed at once, the rest waits for the next run.
+const delayedActionsBatchSize = 100
+
+func (m *ActionHandler) lazyExecuteDelayedActionsTask() {
+	for {
+		time.Sleep(10 * time.Second)
+
+		delayedActions, err := m.pg.Q.GetDueDelayedActions(context.Background(), pgmodel.GetDueDelayedActionsParams{
+			ExecuteAt: time.Now().UTC(),
+			Limit:     delayedActionsBatchSize,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to retrieve due delayed actions")
+			continue
+		}
+
+		for _, delayedAction := range delayedActions {
+			// The delayed action is deleted before it's executed to make sure it doesn't run twice
+			_, err := m.pg.Q.DeleteDelayedAction(context.Background(), pgmodel.DeleteDelayedActionParams{
+				ID:      delayedAction.ID,
+				GuildID: delayedAction.GuildID,
+			})
+			if err != nil {
+				if err != sql.ErrNoRows {
+					log.Error().Err(err).Msg("Failed to delete delayed action")
+				}
+				continue
+			}
+
+			err = m.executeDelayedAction(context.Background(), delayedAction)
+			if err != nil {
+				log.Error().Err(err).Str("guild_id", delayedAction.GuildID).Msg("Failed to execute delayed action")
+			}
+		}
+	}
+}
+
+func (m *ActionHandler) executeDelayedAction(ctx context.Context, delayedAction pgmodel.DelayedAction) error {
+	interaction := &discordgo.Interaction{}
+	err := json.Unmarshal(delayedAction.Interaction, interaction)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal interaction: %w", err)
+	}
+
+	s, err := m.bot.GetSessionForGuild(ctx, delayedAction.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// The roles of the member have likely changed since the interaction was created
+	member, err := s.GuildMember(delayedAction.GuildID, delayedAction.UserID, discordgo.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("Failed to get member: %w", err)
+	}
+	if interaction.Member != nil {
+		member.Permissions = interaction.Member.Permissions
+	}
+	interaction.Member = member
+
+	actionSet, e, err := m.newActionExecution(s, &DelayedInteraction{Inner: interaction}, delayedAction.SourceID, delayedAction.Actions, delayedAction.DerivedPermissions)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.executeActions(e, actionSet.Actions, nil)
+	m.insertActionLog(e, err)
+	return err
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/template"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/util"
+	"github.com/rs/zerolog/log"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const formCustomIDPrefix = "action:form:"
+
+// openForm responds to the interaction with a modal for the given form.
+// The custom id of the modal references the form action, so it can be found again when the form is submitted.
+func (m *ActionHandler) openForm(e *actionExecution, form *actions.ActionForm, path []int) {
+	if form == nil || len(form.Fields) == 0 {
+		return
+	}
+
+	if e.i.HasResponded() || e.interaction.Type == discordgo.InteractionModalSubmit {
+		respondError(e, "Forms can only be opened as the first response to a component or command.")
+		return
+	}
+
+	if err := form.Validate(); err != nil {
+		respondError(e, fmt.Sprintf("Invalid form: %s", err))
+		return
+	}
+
+	title, ok := executeTemplate(e, form.Title)
+	if !ok {
+		return
+	}
+
+	components := make([]discordgo.MessageComponent, len(form.Fields))
+	for i, field := range form.Fields {
+		value, ok := executeTemplate(e, field.Value)
+		if !ok {
+			return
+		}
+
+		style := field.Style
+		if style == 0 {
+			style = discordgo.TextInputShort
+		}
+
+		components[i] = discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    field.Name,
+					Label:       field.Label,
+					Style:       style,
+					Placeholder: field.Placeholder,
+					Value:       value,
+					Required:    field.Required,
+					MinLength:   field.MinLength,
+					MaxLength:   field.MaxLength,
+				},
+			},
+		}
+	}
+
+	e.i.Respond(&discordgo.InteractionResponseData{
+		CustomID:   formCustomIDPrefix + e.sourceID + ":" + formatActionPath(path),
+		Title:      title,
+		Components: components,
+	}, discordgo.InteractionResponseModal)
+}
+
+func (m *ActionHandler) handleFormSubmit(s *discordgo.Session, i Interaction) error {
+	interaction := i.Interaction()
+	data := interaction.ModalSubmitData()
+
+	if !strings.HasPrefix(data.CustomID, formCustomIDPrefix) {
+		return nil
+	}
+
+	sourceID, rawPath, ok := strings.Cut(data.CustomID[len(formCustomIDPrefix):], ":")
+	if !ok {
+		return nil
+	}
+
+	path, err := parseActionPath(rawPath)
+	if err != nil {
+		return nil
+	}
+
+	var rawActions []byte
+	var rawDerivedPerms pqtype.NullRawMessage
+	var messageID sql.NullString
+	if interaction.Message != nil {
+		// The form was opened by a component on a message
+		col, err := m.pg.Q.GetMessageActionSet(context.TODO(), pgmodel.GetMessageActionSetParams{
+			MessageID: interaction.Message.ID,
+			SetID:     sourceID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+
+			log.Error().Err(err).Msg("Failed to get message action set")
+			return err
+		}
+		rawActions = col.Actions
+		rawDerivedPerms = col.DerivedPermissions
+		messageID = sql.NullString{String: interaction.Message.ID, Valid: true}
+	} else {
+		// The form was opened by a custom command
+		col, err := m.pg.Q.GetCustomCommand(context.TODO(), pgmodel.GetCustomCommandParams{
+			ID:      sourceID,
+			GuildID: interaction.GuildID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+
+			log.Error().Err(err).Msg("Failed to get custom command action set")
+			return err
+		}
+		rawActions = col.Actions
+		rawDerivedPerms = col.DerivedPermissions
+	}
+
+	actionSet, e, err := m.newActionExecution(s, i, sourceID, rawActions, rawDerivedPerms)
+	if err != nil {
+		return err
+	}
+
+	action := findActionByPath(actionSet.Actions, path)
+	if action == nil || action.Type != actions.ActionTypeModalForm || action.Form == nil {
+		respondError(e, "This form doesn't exist anymore.")
+		return nil
+	}
+
+	values := template.NewFormData(data)
+	fields := make([]actions.FormSubmissionField, 0, len(action.Form.Fields))
+	for _, field := range action.Form.Fields {
+		value, ok := values[field.Name]
+		if !ok {
+			continue
+		}
+
+		fields = append(fields, actions.FormSubmissionField{
+			Name:  field.Name,
+			Label: field.Label,
+			Value: value,
+		})
+	}
+
+	rawFields, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.pg.Q.InsertFormSubmission(context.TODO(), pgmodel.InsertFormSubmissionParams{
+		ID:        util.UniqueID(),
+		GuildID:   interaction.GuildID,
+		ChannelID: interaction.ChannelID,
+		MessageID: messageID,
+		UserID:    interactionUserID(interaction),
+		Title:     action.Form.Title,
+		Fields:    rawFields,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to insert form submission")
+	}
+
+	// The actions that come after the form are executed once the actions of the form have finished
+	e.continuations = continuationsForPath(actionSet.Actions, path)
+	ok, err = m.executeActions(e, action.Form.ActionSet.Actions, append(path, 0))
+	for ok && err == nil && len(e.continuations) != 0 {
+		last := len(e.continuations) - 1
+		remaining := e.continuations[last]
+		e.continuations = e.continuations[:last]
+		ok, err = m.executeActions(e, remaining, nil)
+	}
+	if err == nil {
+		respondDefault(e)
+	}
+
+	m.insertActionLog(e, err)
+	return err
+}
+
+// findActionByPath returns the action at the given path.
+// A path alternates between the index of an action and the index of one of its nested action sets.
+func findActionByPath(actionList []actions.Action, path []int) *actions.Action {
+	if len(path) == 0 || path[0] < 0 || path[0] >= len(actionList) {
+		return nil
+	}
+
+	action := &actionList[path[0]]
+	if len(path) == 1 {
+		return action
+	}
+
+	nested := action.NestedActionSets()
+	if len(path) < 3 || path[1] < 0 || path[1] >= len(nested) {
+		return nil
+	}
+
+	return findActionByPath(nested[path[1]].Actions, path[2:])
+}
+
+// continuationsForPath returns the actions that come after the action at the given path in each of the enclosing action lists, innermost last.
+func continuationsForPath(actionList []actions.Action, path []int) [][]actions.Action {
+	var res [][]actions.Action
+	for len(path) != 0 && path[0] >= 0 && path[0] < len(actionList) {
+		res = append(res, actionList[path[0]+1:])
+
+		nested := actionList[path[0]].NestedActionSets()
+		if len(path) < 3 || path[1] < 0 || path[1] >= len(nested) {
+			break
+		}
+
+		actionList = nested[path[1]].Actions
+		path = path[2:]
+	}
+
+	return res
+}
+
+func formatActionPath(path []int) string {
+	parts := make([]string, len(path))
+	for i, index := range path {
+		parts[i] = strconv.Itoa(index)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func parseActionPath(raw string) ([]int, error) {
+	parts := strings.Split(raw, ".")
+
+	path := make([]int, len(parts))
+	for i, part := range parts {
+		index, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		path[i] = index
+	}
+
+	return path, nil
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+)
+
+func textActions(texts ...string) []actions.Action {
+	res := make([]actions.Action, len(texts))
+	for i, text := range texts {
+		res[i] = actions.Action{Type: actions.ActionTypeTextResponse, Text: text}
+	}
+	return res
+}
+
+func TestContinuationsForPath(t *testing.T) {
+	form := actions.Action{Type: actions.ActionTypeModalForm, Form: &actions.ActionForm{}}
+
+	root := append(textActions("a"), form)
+	root = append(root, textActions("b", "c")...)
+
+	branch := append([]actions.Action{form}, textActions("d")...)
+	nested := append(textActions("e"), actions.Action{
+		Type: actions.ActionTypeConditional,
+		Condition: &actions.ActionCondition{
+			Then: actions.ActionSet{Actions: textActions("f")},
+			Else: actions.ActionSet{Actions: branch},
+		},
+	})
+	nested = append(nested, textActions("g")...)
+
+	tests := []struct {
+		name       string
+		actionList []actions.Action
+		path       []int
+		want       [][]actions.Action
+	}{
+		{name: "empty path", actionList: root, path: nil, want: nil},
+		{name: "out of range", actionList: root, path: []int{4}, want: nil},
+		{name: "top level", actionList: root, path: []int{1}, want: [][]actions.Action{textActions("b", "c")}},
+		{name: "last action", actionList: root, path: []int{3}, want: [][]actions.Action{{}}},
+		{
+			name:       "nested branch",
+			actionList: nested,
+			path:       []int{1, 1, 0},
+			want:       [][]actions.Action{textActions("g"), textActions("d")},
+		},
+		{
+			name:       "unknown branch",
+			actionList: nested,
+			path:       []int{1, 2, 0},
+			want:       [][]actions.Action{textActions("g")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := continuationsForPath(tt.actionList, tt.path)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("continuationsForPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
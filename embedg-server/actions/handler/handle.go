@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/merlinfuchs/discordgo"
 	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
@@ -16,6 +18,7 @@ import (
 	"github.com/merlinfuchs/embed-generator/embedg-server/actions/variables"
 	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
 	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/model"
 	"github.com/merlinfuchs/embed-generator/embedg-server/store"
 	"github.com/rs/zerolog/log"
 	"github.com/sqlc-dev/pqtype"
@@ -24,23 +27,73 @@ import (
 const roleErrorMessage = "Failed to add or remove role.\n\n" +
 	"Please make sure the role is below the 'Embed Generator' role and that the bot has the manage roles permission."
 
+// Bot is used for actions that aren't tied to the interaction, e.g. sending messages to other channels.
+type Bot interface {
+	GetSessionForGuild(ctx context.Context, guildID string) (*discordgo.Session, error)
+	SendMessageToChannel(ctx context.Context, channelID string, params *discordgo.WebhookParams) (*discordgo.Message, error)
+	EditMessageInChannel(ctx context.Context, channelID string, messageID string, params *discordgo.WebhookEdit) (*discordgo.Message, error)
+	AddReactionsToMessage(ctx context.Context, channelID string, messageID string, reactions []actions.ReactionWithActions) error
+}
+
 type ActionHandler struct {
-	pg        *postgres.PostgresStore
-	parser    *parser.ActionParser
-	planStore store.PlanStore
+	pg            *postgres.PostgresStore
+	parser        *parser.ActionParser
+	planStore     store.PlanStore
+	bot           Bot
+	kvStore       store.KVEntryStore
+	requestClient *http.Client
+	// random picks the choices of random choice actions, see WithRandomSeed to make them predictable
+	random randomSource
+	// recorder is only set when the actions are simulated
+	recorder *simulationRecorder
 }
 
-func New(pg *postgres.PostgresStore, parser *parser.ActionParser, planStore store.PlanStore) *ActionHandler {
-	return &ActionHandler{
-		pg:        pg,
-		parser:    parser,
-		planStore: planStore,
+// Option changes the default behavior of the action handler.
+type Option func(m *ActionHandler)
+
+func New(pg *postgres.PostgresStore, parser *parser.ActionParser, planStore store.PlanStore, bot Bot, opts ...Option) *ActionHandler {
+	m := &ActionHandler{
+		pg:            pg,
+		parser:        parser,
+		planStore:     planStore,
+		bot:           bot,
+		kvStore:       pg,
+		requestClient: requestClient,
+		random:        newRandomSource(time.Now().UnixNano()),
 	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	go m.lazyExecuteDelayedActionsTask()
+	go m.lazyDeleteExpiredActionLogsTask()
+	go m.lazyRemoveTemporaryRolesTask()
+
+	return m
+}
+
+type actionExecution struct {
+	s                 *discordgo.Session
+	i                 Interaction
+	interaction       *discordgo.Interaction
+	sourceID          string
+	derivedPerms      actions.ActionDerivedPermissions
+	legacyPermissions bool
+	features          model.PlanFeatures
+	variables         *variables.VariableContext
+	templates         *template.TemplateContext
+	// continuations contains the remaining actions of the enclosing action lists, innermost last
+	continuations [][]actions.Action
+	// executed and failure are written to the action log once the execution has finished
+	executed []actions.ActionType
+	failure  string
 }
 
 func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interaction) error {
 	interaction := i.Interaction()
 
+	var sourceID string
 	var rawActions []byte
 	var rawDerivedPerms pqtype.NullRawMessage
 	if interaction.Type == discordgo.InteractionMessageComponent {
@@ -53,7 +106,11 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 		actionSetID := data.CustomID[7:]
 
 		if strings.HasPrefix(actionSetID, "options:") {
-			actionSetID = data.Values[0][7:]
+			return m.handleSelectMenuInteraction(s, i)
+		}
+
+		if strings.HasPrefix(actionSetID, bookActionSetIDPrefix) {
+			return m.handleBookInteraction(s, i)
 		}
 
 		col, err := m.pg.Q.GetMessageActionSet(context.TODO(), pgmodel.GetMessageActionSetParams{
@@ -68,6 +125,7 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 			log.Error().Err(err).Msg("Failed to get message action set")
 			return err
 		}
+		sourceID = actionSetID
 		rawActions = col.Actions
 		rawDerivedPerms = col.DerivedPermissions
 	} else if interaction.Type == discordgo.InteractionApplicationCommand {
@@ -93,50 +151,168 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 			log.Error().Err(err).Msg("Failed to get custom command action set")
 			return err
 		}
+		sourceID = col.ID
 		rawActions = col.Actions
 		rawDerivedPerms = col.DerivedPermissions
+	} else if interaction.Type == discordgo.InteractionModalSubmit {
+		return m.handleFormSubmit(s, i)
 	} else {
 		return fmt.Errorf("invalid interaciont type")
 	}
 
-	actionSet := actions.ActionSet{}
-	err := json.Unmarshal(rawActions, &actionSet)
+	actionSet, e, err := m.newActionExecution(s, i, sourceID, rawActions, rawDerivedPerms)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to unmarshal action set")
 		return err
 	}
 
-	// For messages created before the permission context was added we don't run permission checks here
-	legacyPermissions := true
-	derivedPerms := actions.ActionDerivedPermissions{}
-	if rawDerivedPerms.Valid {
-		err = json.Unmarshal(rawDerivedPerms.RawMessage, &derivedPerms)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to unmarshal permission context")
+	if actionSet.HasLimits() {
+		ok, err := m.checkActionSetLimits(e, &actionSet)
+		if err != nil || !ok {
 			return err
 		}
-		legacyPermissions = false
 	}
 
-	// DEPRECATED: This has been replaced by templates, it's only here for backwards compatibility
-	variables := variables.NewContext(
-		variables.NewInteractionVariables(interaction),
-		variables.NewGuildVariables(interaction.GuildID, s.State, nil),
-		variables.NewChannelVariables(interaction.ChannelID, s.State, nil),
-	)
+	err = m.executeActionSet(e, actionSet.Actions, nil)
+	m.insertActionLog(e, err)
+	return err
+}
+
+func (m *ActionHandler) newActionExecution(
+	s *discordgo.Session,
+	i Interaction,
+	sourceID string,
+	rawActions []byte,
+	rawDerivedPerms pqtype.NullRawMessage,
+) (actions.ActionSet, *actionExecution, error) {
+	interaction := i.Interaction()
+
+	actionSet := actions.ActionSet{}
+	err := json.Unmarshal(rawActions, &actionSet)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal action set")
+		return actionSet, nil, err
+	}
+
+	derivedPerms, legacyPermissions, err := parseDerivedPermissions(rawDerivedPerms)
+	if err != nil {
+		return actionSet, nil, err
+	}
 
 	features, err := m.planStore.GetPlanFeaturesForGuild(context.TODO(), interaction.GuildID)
 	if err != nil {
-		return fmt.Errorf("could not get plan features: %w", err)
+		return actionSet, nil, fmt.Errorf("could not get plan features: %w", err)
+	}
+
+	e := &actionExecution{
+		s:                 s,
+		i:                 i,
+		interaction:       interaction,
+		sourceID:          sourceID,
+		derivedPerms:      derivedPerms,
+		legacyPermissions: legacyPermissions,
+		features:          features,
+		// DEPRECATED: This has been replaced by templates, it's only here for backwards compatibility
+		variables: variables.NewContext(
+			variables.NewInteractionVariables(interaction),
+			variables.NewGuildVariables(interaction.GuildID, s.State, nil),
+			variables.NewChannelVariables(interaction.ChannelID, s.State, nil),
+		),
+		templates: template.NewContext(
+			"HANDLE_ACTION", features.MaxTemplateOps,
+			template.NewInteractionProvider(s.State, interaction),
+			template.NewStateProvider(s.State, interaction.GuildID),
+			template.NewKVProvider(interaction.GuildID, m.kvStore, features.MaxKVKeys),
+			template.NewSnippetProvider(interaction.GuildID, m.pg, features.MaxTemplateSnippetDepth, features.MaxTemplateSnippetsSize),
+		),
+	}
+
+	err = m.resolveLibraryActionSet(e, &actionSet)
+	if err != nil {
+		return actionSet, nil, err
+	}
+
+	return actionSet, e, nil
+}
+
+// parseDerivedPermissions returns the permission context that has been stored with the actions.
+// For messages created before the permission context was added we don't run permission checks, this is indicated by the second return value.
+func parseDerivedPermissions(rawDerivedPerms pqtype.NullRawMessage) (actions.ActionDerivedPermissions, bool, error) {
+	derivedPerms := actions.ActionDerivedPermissions{}
+	if !rawDerivedPerms.Valid {
+		return derivedPerms, true, nil
+	}
+
+	err := json.Unmarshal(rawDerivedPerms.RawMessage, &derivedPerms)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal permission context")
+		return derivedPerms, false, err
+	}
+
+	return derivedPerms, false, nil
+}
+
+// executeActionSet runs the actions and sends a default response if none of the actions has responded.
+// The path is the location of the action list inside the root action set and is used to reference nested actions.
+func (m *ActionHandler) executeActionSet(e *actionExecution, actionList []actions.Action, path []int) error {
+	_, err := m.executeActions(e, actionList, path)
+	if err != nil {
+		return err
 	}
 
-	templates := template.NewContext(
-		"HANDLE_ACTION", features.MaxTemplateOps,
-		template.NewInteractionProvider(s.State, interaction),
-		template.NewKVProvider(interaction.GuildID, m.pg, features.MaxKVKeys),
-	)
+	respondDefault(e)
+	return nil
+}
+
+// respondDefault acknowledges the interaction if none of the actions has responded yet.
+func respondDefault(e *actionExecution) {
+	if e.i.HasResponded() {
+		return
+	}
+
+	// Forms that have been opened by a component belong to a message, so they can be acknowledged like the component
+	if e.interaction.Type == discordgo.InteractionMessageComponent || (e.interaction.Type == discordgo.InteractionModalSubmit && e.interaction.Message != nil) {
+		e.i.Respond(nil, discordgo.InteractionResponseDeferredMessageUpdate)
+	} else if e.interaction.Type == discordgo.InteractionModalSubmit {
+		e.i.Respond(&discordgo.InteractionResponseData{
+			Content: "Your form has been submitted.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	} else {
+		e.i.Respond(&discordgo.InteractionResponseData{
+			Content: "No response",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+}
+
+// canRespond returns whether the interaction can still be responded to.
+// Actions that are executed after a wait or for a reaction don't have an interaction that can be responded to.
+func canRespond(e *actionExecution) bool {
+	_, delayed := e.i.(*DelayedInteraction)
+	return !delayed
+}
+
+// executeActions runs the actions in order and returns false if the execution was stopped early.
+func (m *ActionHandler) executeActions(e *actionExecution, actionList []actions.Action, path []int) (bool, error) {
+	s := e.s
+	i := e.i
+	interaction := e.interaction
+	derivedPerms := e.derivedPerms
+	legacyPermissions := e.legacyPermissions
+	variables := e.variables
+
+	for actionIndex, action := range actionList {
+		// Poll votes are still counted, only the edit of the message is skipped by editMessageWithSavedMessage
+		if !canRespond(e) && action.RespondsToInteraction() && action.Type != actions.ActionTypePollVote {
+			log.Warn().
+				Str("guild_id", interaction.GuildID).
+				Int("action_type", int(action.Type)).
+				Msg("Skipping action that responds to an interaction that can't be responded to anymore")
+			continue
+		}
+
+		e.executed = append(e.executed, action.Type)
 
-	for _, action := range actionSet.Actions {
 		switch action.Type {
 		case actions.ActionTypeTextResponse:
 			var flags discordgo.MessageFlags
@@ -144,126 +320,23 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 				flags = discordgo.MessageFlagsEphemeral
 			}
 
-			content, ok := executeTemplate(i, templates, variables.FillString(action.Text))
+			content, ok := executeTemplate(e, variables.FillString(action.Text))
 			if !ok {
-				return nil
+				return false, nil
 			}
 
 			i.Respond(&discordgo.InteractionResponseData{
 				Content: content,
 				Flags:   flags,
 			})
-		case actions.ActionTypeToggleRole:
-			if !legacyPermissions && !derivedPerms.CanManageRole(action.TargetID) {
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("The user that has created this message doesn't have permissions to toggle the role <@&%s>.", action.TargetID),
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-				return nil
-			}
-
-			hasRole := false
-			for _, roleID := range interaction.Member.Roles {
-				if roleID == action.TargetID {
-					hasRole = true
-				}
-			}
-
-			var err error
-			if hasRole {
-				err = s.GuildMemberRoleRemove(interaction.GuildID, interaction.Member.User.ID, action.TargetID)
-				if err == nil {
-					if !action.DisableDefaultResponse {
-						i.Respond(&discordgo.InteractionResponseData{
-							Content: fmt.Sprintf("Removed role <@&%s>", action.TargetID),
-							Flags:   discordgo.MessageFlagsEphemeral,
-						})
-					}
-				}
-			} else {
-				err = s.GuildMemberRoleAdd(interaction.GuildID, interaction.Member.User.ID, action.TargetID)
-				if err == nil {
-					if !action.DisableDefaultResponse {
-						i.Respond(&discordgo.InteractionResponseData{
-							Content: fmt.Sprintf("Added role <@&%s>", action.TargetID),
-							Flags:   discordgo.MessageFlagsEphemeral,
-						})
-					}
-				}
-			}
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to toggle role")
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: roleErrorMessage,
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-			}
-		case actions.ActionTypeAddRole:
-			if !legacyPermissions && !derivedPerms.CanManageRole(action.TargetID) {
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("The user that has created this message doesn't have permissions to assign the role <@&%s>.", action.TargetID),
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-				return nil
-			}
-
-			err := s.GuildMemberRoleAdd(interaction.GuildID, interaction.Member.User.ID, action.TargetID)
-			if err == nil {
-				if !action.DisableDefaultResponse {
-					i.Respond(&discordgo.InteractionResponseData{
-						Content: fmt.Sprintf("Added role <@&%s>", action.TargetID),
-						Flags:   discordgo.MessageFlagsEphemeral,
-					})
-				}
-			} else {
-				log.Error().Err(err).Msg("Failed to add role")
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: roleErrorMessage,
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-			}
-		case actions.ActionTypeRemoveRole:
-			if !legacyPermissions && !derivedPerms.CanManageRole(action.TargetID) {
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("The user that has created this message doesn't have permissions to remove the role <@&%s>.", action.TargetID),
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-				return nil
-			}
-
-			err := s.GuildMemberRoleRemove(interaction.GuildID, interaction.Member.User.ID, action.TargetID)
-			if err == nil {
-				if !action.DisableDefaultResponse {
-					i.Respond(&discordgo.InteractionResponseData{
-						Content: fmt.Sprintf("Removed role <@&%s>", action.TargetID),
-						Flags:   discordgo.MessageFlagsEphemeral,
-					})
-				}
-			} else {
-				log.Error().Err(err).Msg("Failed to remove role")
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: roleErrorMessage,
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
+		case actions.ActionTypeToggleRole, actions.ActionTypeAddRole, actions.ActionTypeRemoveRole:
+			if !m.executeRoleAction(e, &action) {
+				return false, nil
 			}
 		case actions.ActionTypeSavedMessageResponse:
-			msg, err := m.pg.Q.GetSavedMessageForGuild(context.TODO(), pgmodel.GetSavedMessageForGuildParams{
-				GuildID: sql.NullString{Valid: true, String: interaction.GuildID},
-				ID:      action.TargetID,
-			})
-			if err != nil {
-				return err
-			}
-
-			data := &actions.MessageWithActions{}
-			err = json.Unmarshal(msg.Data, data)
-			if err != nil {
-				return err
-			}
-
-			variables.FillMessage(data)
-			if !executeTemplateMessage(i, templates, data) {
-				return nil
+			data, ok, err := m.prepareSavedMessage(e, action.TargetID)
+			if err != nil || !ok {
+				return false, err
 			}
 
 			var flags discordgo.MessageFlags
@@ -275,7 +348,7 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 			if !legacyPermissions {
 				components, err = m.parser.ParseMessageComponents(data.Components)
 				if err != nil {
-					return fmt.Errorf("Invalid actions: %w", err)
+					return false, fmt.Errorf("Invalid actions: %w", err)
 				}
 			}
 
@@ -296,31 +369,31 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 				err = m.parser.CreateActionsForMessage(context.TODO(), data.Actions, derivedPerms, newMsg.ID, !action.Public)
 				if err != nil {
 					log.Error().Err(err).Msg("failed to create actions for message")
-					return err
+					return false, err
+				}
+
+				err = m.parser.CreatePollForMessage(context.TODO(), data.Actions, interaction.GuildID, interaction.ChannelID, newMsg.ID)
+				if err != nil {
+					log.Error().Err(err).Msg("failed to create poll for message")
+					return false, err
 				}
 			}
 		case actions.ActionTypeTextDM:
 			dmChannel, err := s.UserChannelCreate(interaction.Member.User.ID)
 			if err != nil {
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: "Failed to send DM",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-				return nil
+				respondError(e, "Failed to send DM")
+				return false, nil
 			}
 
-			content, ok := executeTemplate(i, templates, variables.FillString(action.Text))
+			content, ok := executeTemplate(e, variables.FillString(action.Text))
 			if !ok {
-				return nil
+				return false, nil
 			}
 
 			_, err = s.ChannelMessageSend(dmChannel.ID, content)
 			if err != nil {
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: "Failed to send DM",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-				return nil
+				respondError(e, "Failed to send DM")
+				return false, nil
 			}
 
 			i.Respond(&discordgo.InteractionResponseData{
@@ -328,32 +401,15 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 				Flags:   discordgo.MessageFlagsEphemeral,
 			})
 		case actions.ActionTypeSavedMessageDM:
-			msg, err := m.pg.Q.GetSavedMessageForGuild(context.TODO(), pgmodel.GetSavedMessageForGuildParams{
-				GuildID: sql.NullString{Valid: true, String: interaction.GuildID},
-				ID:      action.TargetID,
-			})
-			if err != nil {
-				return err
-			}
-
-			data := &actions.MessageWithActions{}
-			err = json.Unmarshal(msg.Data, data)
-			if err != nil {
-				return err
-			}
-
-			variables.FillMessage(data)
-			if !executeTemplateMessage(i, templates, data) {
-				return nil
+			data, ok, err := m.prepareSavedMessage(e, action.TargetID)
+			if err != nil || !ok {
+				return false, err
 			}
 
 			dmChannel, err := s.UserChannelCreate(interaction.Member.User.ID)
 			if err != nil {
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: "Failed to send DM",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-				return nil
+				respondError(e, "Failed to send DM")
+				return false, nil
 			}
 
 			_, err = s.ChannelMessageSendComplex(dmChannel.ID, &discordgo.MessageSend{
@@ -361,11 +417,8 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 				Embeds:  data.Embeds,
 			})
 			if err != nil {
-				i.Respond(&discordgo.InteractionResponseData{
-					Content: "Failed to send DM",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				})
-				return nil
+				respondError(e, "Failed to send DM")
+				return false, nil
 			}
 
 			i.Respond(&discordgo.InteractionResponseData{
@@ -373,9 +426,9 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 				Flags:   discordgo.MessageFlagsEphemeral,
 			})
 		case actions.ActionTypeTextEdit:
-			content, ok := executeTemplate(i, templates, variables.FillString(action.Text))
+			content, ok := executeTemplate(e, variables.FillString(action.Text))
 			if !ok {
-				return nil
+				return false, nil
 			}
 
 			i.Respond(&discordgo.InteractionResponseData{
@@ -386,46 +439,121 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 				continue
 			}
 
-			msg, err := m.pg.Q.GetSavedMessageForGuild(context.TODO(), pgmodel.GetSavedMessageForGuildParams{
-				GuildID: sql.NullString{Valid: true, String: interaction.GuildID},
-				ID:      action.TargetID,
-			})
-			if err != nil {
-				return err
+			ok, err := m.editMessageWithSavedMessage(e, action.TargetID)
+			if err != nil || !ok {
+				return false, err
 			}
-
-			data := &actions.MessageWithActions{}
-			err = json.Unmarshal(msg.Data, data)
-			if err != nil {
-				return err
+		case actions.ActionTypeSavedMessageChannel:
+			// Without a permission context we can't verify that the creator is allowed to send messages in the channel
+			if legacyPermissions {
+				continue
 			}
 
-			variables.FillMessage(data)
-			if !executeTemplateMessage(i, templates, data) {
-				return nil
+			channelIDs := []string{action.ChannelID}
+			if action.TargetSelected {
+				channelIDs = selectedChannelIDs(interaction)
+				if len(channelIDs) == 0 {
+					continue
+				}
 			}
 
-			var components []discordgo.MessageComponent
-			if !legacyPermissions {
-				components, err = m.parser.ParseMessageComponents(data.Components)
-				if err != nil {
-					return fmt.Errorf("Invalid actions: %w", err)
+			targetPerms := make([]actions.ActionDerivedPermissions, len(channelIDs))
+			for x, channelID := range channelIDs {
+				perms, err := m.parser.DerivePermissionsForActions(derivedPerms.UserID, interaction.GuildID, channelID)
+				if err != nil || !perms.HasChannelPermission(discordgo.PermissionManageWebhooks) {
+					respondError(e, fmt.Sprintf("The user that has created this message doesn't have permissions to send messages in the channel <#%s>.", channelID))
+					return false, nil
 				}
+				targetPerms[x] = perms
 			}
 
-			i.Respond(&discordgo.InteractionResponseData{
-				Content:    data.Content,
-				Embeds:     data.Embeds,
-				Components: components,
-			}, discordgo.InteractionResponseUpdateMessage)
+			data, ok, err := m.prepareSavedMessage(e, action.TargetID)
+			if err != nil || !ok {
+				return false, err
+			}
 
-			if !legacyPermissions {
-				ephemeral := interaction.Message.Flags&discordgo.MessageFlagsEphemeral != 0
-				err = m.parser.CreateActionsForMessage(context.TODO(), data.Actions, derivedPerms, interaction.Message.ID, ephemeral)
+			components, err := m.parser.ParseMessageComponents(data.Components)
+			if err != nil {
+				return false, fmt.Errorf("Invalid actions: %w", err)
+			}
+
+			mentions := make([]string, len(channelIDs))
+			for x, channelID := range channelIDs {
+				newMsg, err := m.bot.SendMessageToChannel(context.TODO(), channelID, &discordgo.WebhookParams{
+					Content:         data.Content,
+					Username:        data.Username,
+					AvatarURL:       data.AvatarURL,
+					TTS:             data.TTS,
+					Embeds:          data.Embeds,
+					AllowedMentions: data.AllowedMentions,
+					Components:      components,
+				})
 				if err != nil {
-					log.Error().Err(err).Msg("failed to create actions for message")
-					return err
+					log.Error().Err(err).Msg("Failed to send message to channel")
+					respondError(e, fmt.Sprintf("Failed to send message to the channel <#%s>.", channelID))
+					return false, nil
+				}
+
+				err = m.createActionsAndReactions(e, data, targetPerms[x], newMsg)
+				if err != nil {
+					return false, err
 				}
+
+				mentions[x] = fmt.Sprintf("<#%s>", channelID)
+			}
+
+			if !action.DisableDefaultResponse {
+				i.Respond(&discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("Message has been sent to %s.", strings.Join(mentions, ", ")),
+					Flags:   discordgo.MessageFlagsEphemeral,
+				})
+			}
+		case actions.ActionTypeCreateThread:
+			ok, err := m.createThread(e, &action)
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypeCloseThread:
+			ok, err := m.closeThread(e, &action)
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypePollVote:
+			if interaction.Type != discordgo.InteractionMessageComponent {
+				continue
+			}
+
+			ok, err := m.castVote(e, &action)
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypeSetNickname, actions.ActionTypeTimeout, actions.ActionTypeKick:
+			ok, err := m.moderateMember(e, &action)
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypeDeleteMessage:
+			if interaction.Type != discordgo.InteractionMessageComponent {
+				continue
+			}
+
+			ok, err := m.deleteSourceMessage(e)
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypeDisableComponents:
+			if interaction.Type != discordgo.InteractionMessageComponent {
+				continue
+			}
+
+			ok, err := m.disableComponents(e, &action)
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypeHTTPRequest:
+			ok, err := m.sendHTTPRequest(e, &action)
+			if err != nil || !ok {
+				return false, err
 			}
 		case actions.ActionTypePermissionCheck:
 			perms, _ := strconv.ParseInt(action.Permissions, 10, 64)
@@ -440,7 +568,7 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 					Content: responseText,
 					Flags:   discordgo.MessageFlagsEphemeral,
 				})
-				return nil
+				return false, nil
 			}
 
 			responseText := "You don't have the required roles to use this component or command."
@@ -455,47 +583,213 @@ func (m *ActionHandler) HandleActionInteraction(s *discordgo.Session, i Interact
 							Content: responseText,
 							Flags:   discordgo.MessageFlagsEphemeral,
 						})
-						return nil
+						return false, nil
 					}
 				}
 			}
+		case actions.ActionTypeModalForm:
+			// The actions of the form and the remaining actions are executed when the form is submitted
+			actionPath := append(slices.Clone(path), actionIndex)
+			m.openForm(e, action.Form, actionPath)
+			return false, nil
+		case actions.ActionTypeConditional:
+			if action.Condition == nil {
+				continue
+			}
+
+			res, ok := executeTemplate(e, variables.FillString(action.Condition.Expression))
+			if !ok {
+				return false, nil
+			}
+
+			branch := &action.Condition.Else
+			branchIndex := 1
+			if isTruthy(res) {
+				branch = &action.Condition.Then
+				branchIndex = 0
+			}
+
+			actionPath := append(slices.Clone(path), actionIndex, branchIndex)
+			e.continuations = append(e.continuations, actionList[actionIndex+1:])
+			ok, err := m.executeActions(e, branch.Actions, actionPath)
+			e.continuations = e.continuations[:len(e.continuations)-1]
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypeRandomChoice:
+			choiceIndex := pickChoice(m.random, action.Choices)
+			if choiceIndex == -1 {
+				continue
+			}
+
+			// The picked index is available to the following actions, e.g. to show which choice has been picked
+			e.templates.Set("Choice", choiceIndex)
+
+			actionPath := append(slices.Clone(path), actionIndex, choiceIndex)
+			e.continuations = append(e.continuations, actionList[actionIndex+1:])
+			ok, err := m.executeActions(e, action.Choices[choiceIndex].ActionSet.Actions, actionPath)
+			e.continuations = e.continuations[:len(e.continuations)-1]
+			if err != nil || !ok {
+				return false, err
+			}
+		case actions.ActionTypeWait:
+			duration := time.Duration(action.Duration) * time.Second
+			if duration <= 0 || duration > actions.MaxWaitDuration {
+				respondError(e, fmt.Sprintf("Wait duration must be between 1 second and %d days.", actions.MaxWaitDuration/(24*time.Hour)))
+				return false, nil
+			}
+
+			remaining := slices.Clone(actionList[actionIndex+1:])
+			for j := len(e.continuations) - 1; j >= 0; j-- {
+				remaining = append(remaining, e.continuations[j]...)
+			}
+
+			if len(remaining) == 0 {
+				continue
+			}
+
+			err := m.scheduleDelayedActions(e, remaining, duration)
+			if err != nil {
+				return false, err
+			}
+			return false, nil
 		}
 	}
 
-	if !i.HasResponded() {
-		if interaction.Type == discordgo.InteractionMessageComponent {
-			i.Respond(nil, discordgo.InteractionResponseDeferredMessageUpdate)
-		} else {
-			i.Respond(&discordgo.InteractionResponseData{
-				Content: "No response",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			})
-		}
+	return true, nil
+}
+
+// isTruthy returns whether the result of a condition template should be considered true.
+func isTruthy(res string) bool {
+	switch strings.ToLower(strings.TrimSpace(res)) {
+	case "", "false", "0", "<no value>":
+		return false
+	}
+	return true
+}
+
+// prepareSavedMessage loads the saved message and fills in its variables and templates.
+// Books are opened at their first page. It returns false if the execution should be stopped.
+func (m *ActionHandler) prepareSavedMessage(e *actionExecution, savedMessageID string) (*actions.MessageWithActions, bool, error) {
+	msg, err := m.pg.Q.GetSavedMessageForGuild(context.TODO(), pgmodel.GetSavedMessageForGuildParams{
+		GuildID: sql.NullString{Valid: true, String: e.interaction.GuildID},
+		ID:      savedMessageID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	data := &actions.MessageWithActions{}
+	err = json.Unmarshal(msg.Data, data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err = m.openBook(e, msg.ID, data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	e.variables.FillMessage(data)
+	if !executeTemplateMessage(e, data) {
+		return nil, false, nil
+	}
+
+	return data, true, nil
+}
+
+// createActionsAndReactions stores the actions, poll and reactions of a message that has been sent to a channel and reacts to it.
+func (m *ActionHandler) createActionsAndReactions(e *actionExecution, data *actions.MessageWithActions, derivedPerms actions.ActionDerivedPermissions, msg *discordgo.Message) error {
+	err := m.parser.CreateActionsForMessage(context.TODO(), data.Actions, derivedPerms, msg.ID, false)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create actions for message")
+		return err
+	}
+
+	err = m.parser.CreatePollForMessage(context.TODO(), data.Actions, e.interaction.GuildID, msg.ChannelID, msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create poll for message")
+		return err
+	}
+
+	err = m.parser.CreateReactionsForMessage(context.TODO(), data.Reactions, msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create reactions for message")
+		return err
+	}
+
+	err = m.bot.AddReactionsToMessage(context.TODO(), msg.ChannelID, msg.ID, data.Reactions)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to add reactions to message")
 	}
 
 	return nil
 }
 
-func executeTemplate(i Interaction, templates *template.TemplateContext, text string) (string, bool) {
-	res, err := templates.ParseAndExecute(text)
+// editMessageWithSavedMessage replaces the message of the component with the saved message.
+// The actions of the message are only replaced if the message has been edited through the interaction.
+func (m *ActionHandler) editMessageWithSavedMessage(e *actionExecution, savedMessageID string) (bool, error) {
+	interaction := e.interaction
+
+	if !canRespond(e) {
+		log.Warn().
+			Str("guild_id", interaction.GuildID).
+			Str("saved_message_id", savedMessageID).
+			Msg("Skipping edit of a message with an interaction that can't be responded to anymore")
+		return true, nil
+	}
+
+	data, ok, err := m.prepareSavedMessage(e, savedMessageID)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	var components []discordgo.MessageComponent
+	if !e.legacyPermissions {
+		components, err = m.parser.ParseMessageComponents(data.Components)
+		if err != nil {
+			return false, fmt.Errorf("Invalid actions: %w", err)
+		}
+	}
+
+	e.i.Respond(&discordgo.InteractionResponseData{
+		Content:    data.Content,
+		Embeds:     data.Embeds,
+		Components: components,
+	}, discordgo.InteractionResponseUpdateMessage)
+
+	if !e.legacyPermissions {
+		ephemeral := interaction.Message.Flags&discordgo.MessageFlagsEphemeral != 0
+		err = m.parser.CreateActionsForMessage(context.TODO(), data.Actions, e.derivedPerms, interaction.Message.ID, ephemeral)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create actions for message")
+			return false, err
+		}
+
+		err = m.parser.CreatePollForMessage(context.TODO(), data.Actions, interaction.GuildID, interaction.ChannelID, interaction.Message.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create poll for message")
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func executeTemplate(e *actionExecution, text string) (string, bool) {
+	res, err := e.templates.ParseAndExecute(text)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to execute template")
-		i.Respond(&discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("Failed to execute template variables:\n```%s```", err.Error()),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
+		respondError(e, fmt.Sprintf("Failed to execute template variables:\n```%s```", err.Error()))
 		return "", false
 	}
 	return res, true
 }
 
-func executeTemplateMessage(i Interaction, templates *template.TemplateContext, m *actions.MessageWithActions) bool {
-	if err := templates.ParseAndExecuteMessage(m); err != nil {
+func executeTemplateMessage(e *actionExecution, m *actions.MessageWithActions) bool {
+	if err := e.templates.ParseAndExecuteMessage(m); err != nil {
 		log.Error().Err(err).Msg("Failed to execute template")
-		i.Respond(&discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("Failed to execute template variables:\n```%s```", err.Error()),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
+		respondError(e, fmt.Sprintf("Failed to execute template variables:\n```%s```", err.Error()))
 		return false
 	}
 
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"strings"
+
 	"github.com/merlinfuchs/discordgo"
 	"github.com/rs/zerolog/log"
 )
@@ -11,6 +13,16 @@ type Interaction interface {
 	Respond(data *discordgo.InteractionResponseData, t ...discordgo.InteractionResponseType) *discordgo.Message
 }
 
+// interactionUserID returns the id of the user that has created the interaction.
+func interactionUserID(interaction *discordgo.Interaction) string {
+	if interaction.Member != nil {
+		return interaction.Member.User.ID
+	} else if interaction.User != nil {
+		return interaction.User.ID
+	}
+	return ""
+}
+
 type GatewayInteraction struct {
 	Responded bool
 	Session   *discordgo.Session
@@ -107,3 +119,87 @@ func (i *RestInteraction) Respond(data *discordgo.InteractionResponseData, t ...
 
 	return msg
 }
+
+// DelayedInteraction is used to execute actions after the original interaction has expired or without an interaction at all, e.g. for reactions.
+// It's not possible to respond to the interaction, so all responses are discarded.
+type DelayedInteraction struct {
+	Inner *discordgo.Interaction
+}
+
+func (i *DelayedInteraction) Interaction() *discordgo.Interaction {
+	return i.Inner
+}
+
+func (i *DelayedInteraction) HasResponded() bool {
+	return true
+}
+
+func (i *DelayedInteraction) Respond(data *discordgo.InteractionResponseData, t ...discordgo.InteractionResponseType) *discordgo.Message {
+	log.Debug().Str("interaction_id", i.Inner.ID).Msg("Discarding response to delayed interaction")
+	return nil
+}
+
+// maxMessageContentLength is the maximum number of characters that Discord allows in the content of a message.
+const maxMessageContentLength = 2000
+
+// BufferedInteraction collects plain ephemeral text responses so they can be sent as a single combined response.
+// All other responses are passed through to the inner interaction and the collected responses are sent with them.
+type BufferedInteraction struct {
+	Inner Interaction
+	lines []string
+}
+
+func (i *BufferedInteraction) Interaction() *discordgo.Interaction {
+	return i.Inner.Interaction()
+}
+
+func (i *BufferedInteraction) HasResponded() bool {
+	return i.Inner.HasResponded()
+}
+
+func (i *BufferedInteraction) Respond(data *discordgo.InteractionResponseData, t ...discordgo.InteractionResponseType) *discordgo.Message {
+	isMessage := len(t) == 0 || t[0] == discordgo.InteractionResponseChannelMessageWithSource
+	isEphemeral := data != nil && data.Flags&discordgo.MessageFlagsEphemeral != 0
+	if isMessage && isEphemeral && data.Content != "" && len(data.Embeds) == 0 && len(data.Components) == 0 && len(data.Files) == 0 {
+		i.lines = append(i.lines, data.Content)
+		return nil
+	}
+
+	// Ephemeral messages can take the collected responses in front of their own content
+	if isMessage && isEphemeral && len(i.lines) != 0 {
+		content := strings.Join(append(i.lines, data.Content), "\n")
+		if len([]rune(content)) <= maxMessageContentLength {
+			combined := *data
+			combined.Content = content
+			data = &combined
+			i.lines = nil
+		}
+	}
+
+	msg := i.Inner.Respond(data, t...)
+
+	// A deferred message has to be followed by the actual message, the collected responses are sent after it
+	if len(t) == 0 || t[0] != discordgo.InteractionResponseDeferredChannelMessageWithSource {
+		i.Flush()
+	}
+
+	return msg
+}
+
+// Flush sends the collected responses as a single response.
+func (i *BufferedInteraction) Flush() {
+	if len(i.lines) == 0 {
+		return
+	}
+
+	content := strings.Join(i.lines, "\n")
+	if runes := []rune(content); len(runes) > maxMessageContentLength {
+		content = string(runes[:maxMessageContentLength])
+	}
+	i.lines = nil
+
+	i.Inner.Respond(&discordgo.InteractionResponseData{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+// getLibraryActionSet returns the current version of an action set from the library of the guild.
+// It returns nil if the action set doesn't exist anymore.
+func (m *ActionHandler) getLibraryActionSet(guildID string, libraryID string) (*actions.ActionSet, *pgmodel.GuildActionSet, error) {
+	row, err := m.pg.Q.GetGuildActionSet(context.TODO(), pgmodel.GetGuildActionSetParams{
+		ID:      libraryID,
+		GuildID: guildID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil
+		}
+
+		log.Error().Err(err).Msg("Failed to get guild action set")
+		return nil, nil, err
+	}
+
+	actionSet := &actions.ActionSet{}
+	err = json.Unmarshal(row.Actions, actionSet)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal guild action set")
+		return nil, nil, err
+	}
+
+	// Library entries can't reference other library entries
+	actionSet.LibraryID = ""
+
+	return actionSet, &row, nil
+}
+
+// resolveLibraryActionSet replaces an action set that references the library with the library entry.
+// Library entries are executed with the permissions that both the user that has last saved them and the message creator have.
+// The limits of the referencing action set take precedence over the limits of the library entry.
+func (m *ActionHandler) resolveLibraryActionSet(e *actionExecution, actionSet *actions.ActionSet) error {
+	if actionSet.LibraryID == "" {
+		return nil
+	}
+
+	resolved, row, err := m.getLibraryActionSet(e.interaction.GuildID, actionSet.LibraryID)
+	if err != nil {
+		return err
+	}
+	if resolved == nil {
+		respondError(e, "The action set that is used here has been deleted from the library of this server.")
+		*actionSet = actions.ActionSet{}
+		return nil
+	}
+
+	derivedPerms := actions.ActionDerivedPermissions{}
+	err = json.Unmarshal(row.DerivedPermissions, &derivedPerms)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal permission context")
+		return err
+	}
+
+	if e.legacyPermissions {
+		e.derivedPerms = derivedPerms
+	} else {
+		e.derivedPerms = e.derivedPerms.Intersect(derivedPerms)
+	}
+	e.legacyPermissions = false
+
+	if actionSet.Cooldown != 0 {
+		resolved.Cooldown = actionSet.Cooldown
+	}
+	if actionSet.MaxUsesPerUser != 0 {
+		resolved.MaxUsesPerUser = actionSet.MaxUsesPerUser
+	}
+	if actionSet.MaxUses != 0 {
+		resolved.MaxUses = actionSet.MaxUses
+	}
+	if actionSet.LimitResponse != "" {
+		resolved.LimitResponse = actionSet.LimitResponse
+	}
+
+	*actionSet = *resolved
+	return nil
+}
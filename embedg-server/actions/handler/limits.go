@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+// checkActionSetLimits enforces the cooldown and usage limits of the action set and records the usage if the user isn't blocked.
+// It returns false if the user has been blocked and has already received a response.
+func (m *ActionHandler) checkActionSetLimits(e *actionExecution, actionSet *actions.ActionSet) (bool, error) {
+	// Custom commands don't have a message, the command id is used as the set id instead
+	messageID := ""
+	if e.interaction.Message != nil {
+		messageID = e.interaction.Message.ID
+	}
+	userID := interactionUserID(e.interaction)
+
+	// The usages of the action set are locked until the usage has been recorded, so concurrent uses can't exceed the limits
+	var limitResponse string
+	err := m.pg.WithTx(context.TODO(), func(pg *postgres.PostgresStore) error {
+		err := pg.Q.LockActionSetUsages(context.TODO(), pgmodel.LockActionSetUsagesParams{
+			MessageID: messageID,
+			SetID:     e.sourceID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to lock action set usages: %w", err)
+		}
+
+		if actionSet.Cooldown > 0 || actionSet.MaxUsesPerUser > 0 {
+			usage, err := pg.Q.GetActionSetUsage(context.TODO(), pgmodel.GetActionSetUsageParams{
+				MessageID: messageID,
+				SetID:     e.sourceID,
+				UserID:    userID,
+			})
+			if err == nil {
+				if actionSet.MaxUsesPerUser > 0 && int(usage.Uses) >= actionSet.MaxUsesPerUser {
+					limitResponse = "You have already used this the maximum number of times."
+					return nil
+				}
+
+				cooldownEnd := usage.LastUsedAt.Add(time.Duration(actionSet.Cooldown) * time.Second)
+				if actionSet.Cooldown > 0 && time.Now().UTC().Before(cooldownEnd) {
+					limitResponse = fmt.Sprintf("You are on cooldown, you can use this again <t:%d:R>.", cooldownEnd.Unix())
+					return nil
+				}
+			} else if err != sql.ErrNoRows {
+				return fmt.Errorf("failed to get action set usage: %w", err)
+			}
+		}
+
+		if actionSet.MaxUses > 0 {
+			totalUses, err := pg.Q.CountActionSetUses(context.TODO(), pgmodel.CountActionSetUsesParams{
+				MessageID: messageID,
+				SetID:     e.sourceID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to count action set uses: %w", err)
+			}
+
+			if totalUses >= int64(actionSet.MaxUses) {
+				limitResponse = "This has already been used the maximum number of times."
+				return nil
+			}
+		}
+
+		_, err = pg.Q.IncreaseActionSetUsage(context.TODO(), pgmodel.IncreaseActionSetUsageParams{
+			MessageID:  messageID,
+			SetID:      e.sourceID,
+			UserID:     userID,
+			LastUsedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to increase action set usage: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check action set limits")
+		return false, err
+	}
+
+	if limitResponse != "" {
+		respondLimited(e, actionSet, limitResponse)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// respondLimited tells the user that they have been blocked, using the custom response of the action set if there is one.
+func respondLimited(e *actionExecution, actionSet *actions.ActionSet, defaultResponse string) {
+	content := defaultResponse
+	if actionSet.LimitResponse != "" {
+		var ok bool
+		content, ok = executeTemplate(e, actionSet.LimitResponse)
+		if !ok {
+			return
+		}
+	}
+
+	e.i.Respond(&discordgo.InteractionResponseData{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+// respondError responds with an ephemeral error message and records it as the reason of the failure in the action log.
+func respondError(e *actionExecution, content string) {
+	e.failure = content
+	e.i.Respond(&discordgo.InteractionResponseData{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// insertActionLog records the executed actions so guild admins can see why actions have failed.
+// The recorded state is reset afterwards, so the execution can be reused for another action set.
+func (m *ActionHandler) insertActionLog(e *actionExecution, execErr error) {
+	defer func() {
+		e.executed = nil
+		e.failure = ""
+	}()
+
+	if e.features.ActionLogRetentionDays <= 0 {
+		return
+	}
+
+	var errorMessage sql.NullString
+	if execErr != nil {
+		errorMessage = sql.NullString{String: execErr.Error(), Valid: true}
+	} else if e.failure != "" {
+		errorMessage = sql.NullString{String: e.failure, Valid: true}
+	}
+
+	executed, err := json.Marshal(e.executed)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal executed actions")
+		return
+	}
+
+	var messageID sql.NullString
+	if e.interaction.Message != nil {
+		messageID = sql.NullString{String: e.interaction.Message.ID, Valid: true}
+	}
+
+	_, err = m.pg.Q.InsertActionLog(context.TODO(), pgmodel.InsertActionLogParams{
+		ID:        util.UniqueID(),
+		GuildID:   e.interaction.GuildID,
+		ChannelID: e.interaction.ChannelID,
+		MessageID: messageID,
+		SourceID:  e.sourceID,
+		UserID:    interactionUserID(e.interaction),
+		Actions:   executed,
+		Success:   !errorMessage.Valid,
+		Error:     errorMessage,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: time.Now().UTC().Add(time.Duration(e.features.ActionLogRetentionDays) * 24 * time.Hour),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to insert action log")
+	}
+}
+
+func (m *ActionHandler) lazyDeleteExpiredActionLogsTask() {
+	for {
+		time.Sleep(30 * time.Minute)
+
+		_, err := m.pg.Q.DeleteExpiredActionLogs(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to delete expired action logs")
+		}
+	}
+}
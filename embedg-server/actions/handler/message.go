@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/rs/zerolog/log"
+)
+
+// deleteSourceMessage deletes the message that the component of the interaction is attached to.
+// It returns false if the execution should be stopped.
+func (m *ActionHandler) deleteSourceMessage(e *actionExecution) (bool, error) {
+	s := e.s
+	i := e.i
+	interaction := e.interaction
+
+	if interaction.Message == nil {
+		return true, nil
+	}
+
+	ephemeral := interaction.Message.Flags&discordgo.MessageFlagsEphemeral != 0
+	if ephemeral {
+		// Ephemeral messages can only be deleted through the interaction that they belong to
+		if i.HasResponded() {
+			respondError(e, "Ephemeral messages can't be deleted after a response has been sent.")
+			return false, nil
+		}
+
+		i.Respond(nil, discordgo.InteractionResponseDeferredMessageUpdate)
+		err := s.InteractionResponseDelete(interaction)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to delete ephemeral message")
+			return false, nil
+		}
+	} else {
+		respondDefault(e)
+
+		err := s.ChannelMessageDelete(interaction.Message.ChannelID, interaction.Message.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to delete message")
+			respondError(e, "Failed to delete message.\n\nPlease make sure that the bot has the manage messages permission.")
+			return false, nil
+		}
+	}
+
+	err := m.pg.Q.DeleteMessageActionSetsForMessage(context.TODO(), interaction.Message.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = m.pg.Q.DeleteMessageReactionsForMessage(context.TODO(), interaction.Message.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = m.pg.Q.DeleteActionSetUsagesForMessage(context.TODO(), interaction.Message.ID)
+	if err != nil {
+		return false, err
+	}
+
+	// The votes are deleted together with the poll
+	err = m.pg.Q.DeletePoll(context.TODO(), interaction.Message.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// disableComponents disables all components of the message that the component of the interaction is attached to.
+// If the action is configured to only disable the clicked component, the other components stay usable.
+func (m *ActionHandler) disableComponents(e *actionExecution, action *actions.Action) (bool, error) {
+	i := e.i
+	interaction := e.interaction
+
+	if interaction.Message == nil || len(interaction.Message.Components) == 0 {
+		return true, nil
+	}
+
+	customID := ""
+	if action.OnlyClicked {
+		customID = interaction.MessageComponentData().CustomID
+	}
+
+	components := interaction.Message.Components
+	for _, component := range components {
+		row, ok := component.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+
+		for _, c := range row.Components {
+			switch c := c.(type) {
+			case *discordgo.Button:
+				if customID == "" || c.CustomID == customID {
+					c.Disabled = true
+				}
+			case *discordgo.SelectMenu:
+				if customID == "" || c.CustomID == customID {
+					c.Disabled = true
+				}
+			}
+		}
+	}
+
+	if !i.HasResponded() {
+		i.Respond(&discordgo.InteractionResponseData{
+			Content:    interaction.Message.Content,
+			Embeds:     interaction.Message.Embeds,
+			Components: components,
+		}, discordgo.InteractionResponseUpdateMessage)
+		return true, nil
+	}
+
+	if interaction.Message.Flags&discordgo.MessageFlagsEphemeral != 0 {
+		respondError(e, "Components of ephemeral messages can't be disabled after a response has been sent.")
+		return false, nil
+	}
+
+	_, err := m.bot.EditMessageInChannel(context.TODO(), interaction.Message.ChannelID, interaction.Message.ID, &discordgo.WebhookEdit{
+		Components: &components,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to disable components")
+		respondError(e, "Failed to disable the components of the message.")
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/rs/zerolog/log"
+)
+
+// maxNicknameLength is the maximum length of nicknames that Discord allows.
+const maxNicknameLength = 32
+
+// moderateMember sets the nickname of, times out or kicks the member that has used the component or command.
+// It returns false if the execution should be stopped.
+func (m *ActionHandler) moderateMember(e *actionExecution, action *actions.Action) (bool, error) {
+	s := e.s
+	i := e.i
+	interaction := e.interaction
+
+	if e.legacyPermissions || interaction.Member == nil {
+		return true, nil
+	}
+
+	var verb, permissionName string
+	var permission int64
+	switch action.Type {
+	case actions.ActionTypeSetNickname:
+		verb, permissionName, permission = "change your nickname", "Manage Nicknames", discordgo.PermissionManageNicknames
+	case actions.ActionTypeTimeout:
+		verb, permissionName, permission = "time you out", "Timeout Members", discordgo.PermissionModerateMembers
+	case actions.ActionTypeKick:
+		verb, permissionName, permission = "kick you", "Kick Members", discordgo.PermissionKickMembers
+	}
+
+	if !e.derivedPerms.HasGuildPermission(permission) {
+		respondError(e, fmt.Sprintf("The user that has created this message doesn't have the %s permission.", permissionName))
+		return false, nil
+	}
+
+	if !e.derivedPerms.CanManageMember(interaction.Member) {
+		respondError(e, fmt.Sprintf("The user that has created this message can't %s, because your highest role is above theirs.", verb))
+		return false, nil
+	}
+
+	var err error
+	var response string
+	switch action.Type {
+	case actions.ActionTypeSetNickname:
+		nickname, ok := executeTemplate(e, e.variables.FillString(action.Text))
+		if !ok {
+			return false, nil
+		}
+		if runes := []rune(nickname); len(runes) > maxNicknameLength {
+			nickname = string(runes[:maxNicknameLength])
+		}
+
+		err = s.GuildMemberNickname(interaction.GuildID, interaction.Member.User.ID, nickname)
+		if nickname == "" {
+			response = "Your nickname has been reset."
+		} else {
+			response = fmt.Sprintf("Your nickname has been changed to %s.", nickname)
+		}
+	case actions.ActionTypeTimeout:
+		duration := time.Duration(action.Duration) * time.Second
+		if duration <= 0 || duration > actions.MaxTimeoutDuration {
+			respondError(e, fmt.Sprintf("Timeout duration must be between 1 second and %d days.", actions.MaxTimeoutDuration/(24*time.Hour)))
+			return false, nil
+		}
+
+		until := time.Now().UTC().Add(duration)
+
+		err = s.GuildMemberTimeout(interaction.GuildID, interaction.Member.User.ID, &until)
+		response = fmt.Sprintf("You have been timed out until <t:%d:f>.", until.Unix())
+	case actions.ActionTypeKick:
+		reason, ok := executeTemplate(e, e.variables.FillString(action.Text))
+		if !ok {
+			return false, nil
+		}
+
+		// The response has to be sent first, the member can't see it anymore after being kicked
+		if !action.DisableDefaultResponse {
+			i.Respond(&discordgo.InteractionResponseData{
+				Content: "You are being kicked from the server.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			})
+		}
+
+		err = s.GuildMemberDeleteWithReason(interaction.GuildID, interaction.Member.User.ID, reason)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to moderate member")
+		respondError(e, fmt.Sprintf(
+			"Failed to %s.\n\nPlease make sure your highest role is below the 'Embed Generator' role and that the bot has the %s permission.",
+			verb, permissionName,
+		))
+		return false, nil
+	}
+
+	if response != "" && !action.DisableDefaultResponse {
+		i.Respond(&discordgo.InteractionResponseData{
+			Content: response,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	return true, nil
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/template"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+// castVote records the vote of the user for the poll of the message, an existing vote of the user is replaced.
+// The message is edited with the saved message afterwards, so the tallies can be rendered with .Poll.
+func (m *ActionHandler) castVote(e *actionExecution, action *actions.Action) (bool, error) {
+	i := e.i
+	interaction := e.interaction
+
+	if action.Poll == nil || interaction.Message == nil {
+		return true, nil
+	}
+
+	// Polls are created when the message is sent, messages that have been sent before get their poll with the first vote
+	var closesAt sql.NullTime
+	if action.Poll.ClosesAt != nil {
+		closesAt = sql.NullTime{Time: action.Poll.ClosesAt.UTC(), Valid: true}
+	}
+
+	var resultsMessageID sql.NullString
+	if action.Poll.ResultsMessageID != "" {
+		resultsMessageID = sql.NullString{String: action.Poll.ResultsMessageID, Valid: true}
+	}
+
+	err := m.pg.Q.InsertPoll(context.TODO(), pgmodel.InsertPollParams{
+		MessageID:        interaction.Message.ID,
+		GuildID:          interaction.GuildID,
+		ChannelID:        interaction.ChannelID,
+		ResultsMessageID: resultsMessageID,
+		ClosesAt:         closesAt,
+		CreatedAt:        time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to insert poll")
+		return false, err
+	}
+
+	poll, err := m.pg.Q.GetPoll(context.TODO(), interaction.Message.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get poll")
+		return false, err
+	}
+
+	if poll.ClosedAt.Valid || (poll.ClosesAt.Valid && !time.Now().UTC().Before(poll.ClosesAt.Time)) {
+		i.Respond(&discordgo.InteractionResponseData{
+			Content: "This poll has been closed.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return false, nil
+	}
+
+	vote, err := m.pg.Q.UpsertPollVote(context.TODO(), pgmodel.UpsertPollVoteParams{
+		MessageID: interaction.Message.ID,
+		UserID:    interactionUserID(interaction),
+		Option:    action.Poll.Option,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to upsert poll vote")
+		return false, err
+	}
+
+	votes, err := m.pg.GetPollVoteCounts(context.TODO(), interaction.Message.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count poll votes")
+		return false, err
+	}
+
+	e.templates.Set("Poll", template.NewPollData(votes, vote.Option, false, poll.ClosesAt.Time))
+
+	if action.TargetID != "" {
+		return m.editMessageWithSavedMessage(e, action.TargetID)
+	}
+
+	if !action.DisableDefaultResponse {
+		i.Respond(&discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Your vote for **%s** has been counted.", action.Poll.Option),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	return true, nil
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+)
+
+type randomSource interface {
+	Intn(n int) int
+}
+
+// lockedRandom makes a seeded random source safe to use from multiple interactions at the same time.
+type lockedRandom struct {
+	sync.Mutex
+	r *rand.Rand
+}
+
+// WithRandomSeed makes the choices of random choice actions predictable by using a fixed seed, e.g. to reproduce issues.
+func WithRandomSeed(seed int64) Option {
+	return func(m *ActionHandler) {
+		m.random = newRandomSource(seed)
+	}
+}
+
+func newRandomSource(seed int64) randomSource {
+	return &lockedRandom{r: rand.New(rand.NewSource(seed))}
+}
+
+func (l *lockedRandom) Intn(n int) int {
+	l.Lock()
+	defer l.Unlock()
+	return l.r.Intn(n)
+}
+
+// pickChoice returns the index of a random choice where each choice is picked with a chance relative to its weight.
+// It returns -1 if there is nothing to pick from.
+func pickChoice(random randomSource, choices []actions.ActionChoice) int {
+	total := 0
+	for _, choice := range choices {
+		if choice.Weight > 0 {
+			total += choice.Weight
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+
+	n := random.Intn(total)
+	for i, choice := range choices {
+		if choice.Weight <= 0 {
+			continue
+		}
+		if n < choice.Weight {
+			return i
+		}
+		n -= choice.Weight
+	}
+
+	return -1
+}
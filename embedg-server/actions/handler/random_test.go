@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+)
+
+// fixedRandom returns the values in order, so the picked choice can be predicted.
+type fixedRandom struct {
+	values []int
+}
+
+func (f *fixedRandom) Intn(n int) int {
+	v := f.values[0]
+	f.values = f.values[1:]
+	return v % n
+}
+
+func weightedChoices(weights ...int) []actions.ActionChoice {
+	choices := make([]actions.ActionChoice, len(weights))
+	for i, weight := range weights {
+		choices[i] = actions.ActionChoice{Weight: weight}
+	}
+	return choices
+}
+
+func TestPickChoice(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []int
+		value   int
+		want    int
+	}{
+		{name: "no choices", weights: nil, value: 0, want: -1},
+		{name: "only zero weights", weights: []int{0, 0}, value: 0, want: -1},
+		{name: "single choice", weights: []int{5}, value: 4, want: 0},
+		{name: "start of first choice", weights: []int{1, 3}, value: 0, want: 0},
+		{name: "start of second choice", weights: []int{1, 3}, value: 1, want: 1},
+		{name: "end of second choice", weights: []int{1, 3}, value: 3, want: 1},
+		{name: "skips zero weight", weights: []int{2, 0, 2}, value: 2, want: 2},
+		{name: "skips negative weight", weights: []int{-5, 1}, value: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pickChoice(&fixedRandom{values: []int{tt.value}}, weightedChoices(tt.weights...))
+			if got != tt.want {
+				t.Errorf("pickChoice() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickChoiceWeighting(t *testing.T) {
+	choices := weightedChoices(1, 2, 7)
+
+	// With a fixed seed the result is the same for every run, so the bounds can be tight
+	random := newRandomSource(42)
+	counts := make([]int, len(choices))
+	for i := 0; i < 10000; i++ {
+		counts[pickChoice(random, choices)]++
+	}
+
+	for i, want := range []int{1000, 2000, 7000} {
+		if counts[i] < want-300 || counts[i] > want+300 {
+			t.Errorf("choice %d picked %d times, want about %d", i, counts[i], want)
+		}
+	}
+}
+
+func TestWithRandomSeed(t *testing.T) {
+	choices := weightedChoices(1, 1, 1, 1)
+
+	a := &ActionHandler{}
+	WithRandomSeed(7)(a)
+	b := &ActionHandler{}
+	WithRandomSeed(7)(b)
+
+	for i := 0; i < 100; i++ {
+		if pickChoice(a.random, choices) != pickChoice(b.random, choices) {
+			t.Fatalf("handlers with the same seed picked different choices at pick %d", i)
+		}
+	}
+}
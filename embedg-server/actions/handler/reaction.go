@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+// GetReactionActionSetID returns the id of the action set that is executed by the emoji of the reaction.
+// It returns false if the emoji of the reaction doesn't execute any actions.
+func (m *ActionHandler) GetReactionActionSetID(reaction *discordgo.MessageReaction) (string, bool, error) {
+	row, err := m.pg.Q.GetMessageReaction(context.TODO(), pgmodel.GetMessageReactionParams{
+		MessageID: reaction.MessageID,
+		Emoji:     actions.ReactionEmojiKey(reaction.Emoji.ID, reaction.Emoji.Name),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+
+		log.Error().Err(err).Msg("Failed to get message reaction")
+		return "", false, err
+	}
+
+	return row.SetID, true, nil
+}
+
+// HandleReaction executes the action set that belongs to the emoji when a member reacts to a message.
+// When the reaction is removed, the roles that have been added by the action set are removed again.
+// The set id is the one that has been stored for the emoji of the reaction, see GetReactionActionSetID.
+func (m *ActionHandler) HandleReaction(s *discordgo.Session, reaction *discordgo.MessageReaction, setID string, member *discordgo.Member, added bool) error {
+	col, err := m.pg.Q.GetMessageActionSet(context.TODO(), pgmodel.GetMessageActionSetParams{
+		MessageID: reaction.MessageID,
+		SetID:     setID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		log.Error().Err(err).Msg("Failed to get message action set")
+		return err
+	}
+
+	// Reactions don't create an interaction, so we make one up to be able to execute the actions in the same way
+	interaction := &discordgo.Interaction{
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{
+			CustomID:      "action:" + setID,
+			ComponentType: discordgo.ButtonComponent,
+		},
+		GuildID:   reaction.GuildID,
+		ChannelID: reaction.ChannelID,
+		Member:    member,
+		Message: &discordgo.Message{
+			ID:        reaction.MessageID,
+			ChannelID: reaction.ChannelID,
+			GuildID:   reaction.GuildID,
+		},
+	}
+
+	actionSet, e, err := m.newActionExecution(s, &DelayedInteraction{Inner: interaction}, setID, col.Actions, col.DerivedPermissions)
+	if err != nil {
+		return err
+	}
+
+	actionList := actionSet.Actions
+	if added {
+		if actionSet.HasLimits() {
+			ok, err := m.checkActionSetLimits(e, &actionSet)
+			if err != nil || !ok {
+				return err
+			}
+		}
+	} else {
+		actionList = reverseRoleActions(actionList)
+		if len(actionList) == 0 {
+			return nil
+		}
+	}
+
+	_, err = m.executeActions(e, actionList, nil)
+	m.insertActionLog(e, err)
+	return err
+}
+
+// reverseRoleActions returns remove role actions for all roles that are added or toggled by the actions.
+// All other actions are dropped because they can't be undone.
+func reverseRoleActions(actionList []actions.Action) []actions.Action {
+	res := make([]actions.Action, 0, len(actionList))
+	for _, action := range actionList {
+		if action.Type != actions.ActionTypeAddRole && action.Type != actions.ActionTypeToggleRole {
+			continue
+		}
+		if action.TargetSelected {
+			continue
+		}
+
+		res = append(res, actions.Action{
+			Type:                   actions.ActionTypeRemoveRole,
+			TargetID:               action.TargetID,
+			DisableDefaultResponse: true,
+		})
+	}
+
+	return res
+}
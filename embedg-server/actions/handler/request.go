@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/template"
+	"github.com/rs/zerolog/log"
+)
+
+const requestTimeout = 5 * time.Second
+
+// maxRequestResponseSize is the maximum size of the response body in bytes.
+const maxRequestResponseSize = 64 * 1024
+
+var requestClient = &http.Client{
+	Timeout: requestTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: requestTimeout,
+			Control: checkRequestAddress,
+		}).DialContext,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: requestTimeout,
+	},
+	// Redirects would make POST requests silently turn into GET requests
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// reservedRequestNetworks are special purpose networks that aren't covered by the checks of net.IP.
+// Some of them are routed to the internal network of the host, e.g. shared address space of carrier-grade NAT.
+var reservedRequestNetworks = parseNetworks(
+	"0.0.0.0/8",       // "This" network
+	"100.64.0.0/10",   // Shared address space (CGNAT)
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // Documentation (TEST-NET-1)
+	"198.18.0.0/15",   // Benchmarking
+	"198.51.100.0/24", // Documentation (TEST-NET-2)
+	"203.0.113.0/24",  // Documentation (TEST-NET-3)
+	"240.0.0.0/4",     // Reserved and limited broadcast
+	"64:ff9b::/96",    // IPv4/IPv6 translation
+	"64:ff9b:1::/48",  // Local-use IPv4/IPv6 translation
+	"100::/64",        // Discard-only
+	"2001::/23",       // IETF protocol assignments
+	"2001:db8::/32",   // Documentation
+	"2002::/16",       // 6to4
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// checkRequestAddress prevents requests to the internal network of the host.
+func checkRequestAddress(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("address %s is not allowed", host)
+	}
+
+	for _, reserved := range reservedRequestNetworks {
+		if reserved.Contains(ip) {
+			return fmt.Errorf("address %s is not allowed", host)
+		}
+	}
+
+	return nil
+}
+
+type requestPayload struct {
+	GuildID         string                    `json:"guild_id"`
+	ChannelID       string                    `json:"channel_id"`
+	MessageID       string                    `json:"message_id,omitempty"`
+	SourceID        string                    `json:"source_id"`
+	UserID          string                    `json:"user_id"`
+	InteractionType discordgo.InteractionType `json:"interaction_type"`
+	Values          []string                  `json:"values,omitempty"`
+	Fields          map[string]string         `json:"fields"`
+	Timestamp       int64                     `json:"timestamp"`
+}
+
+// sendHTTPRequest posts the interaction context and the templated fields to the configured URL.
+// The body is signed with the secret of the guild, the signature is sent as hex encoded HMAC-SHA256 of the timestamp and the body.
+func (m *ActionHandler) sendHTTPRequest(e *actionExecution, action *actions.Action) (bool, error) {
+	interaction := e.interaction
+
+	if e.legacyPermissions || action.Request == nil {
+		return true, nil
+	}
+
+	if !e.features.HTTPRequestActions {
+		respondError(e, "HTTP request actions are not available for this server.")
+		return false, nil
+	}
+
+	fields := make(map[string]string, len(action.Request.Fields))
+	for _, field := range action.Request.Fields {
+		value, ok := executeTemplate(e, e.variables.FillString(field.Value))
+		if !ok {
+			return false, nil
+		}
+		fields[field.Name] = value
+	}
+
+	timestamp := time.Now().UTC().Unix()
+
+	payload := requestPayload{
+		GuildID:         interaction.GuildID,
+		ChannelID:       interaction.ChannelID,
+		SourceID:        e.sourceID,
+		UserID:          interactionUserID(interaction),
+		InteractionType: interaction.Type,
+		Fields:          fields,
+		Timestamp:       timestamp,
+	}
+	if interaction.Message != nil {
+		payload.MessageID = interaction.Message.ID
+	}
+	if interaction.Type == discordgo.InteractionMessageComponent {
+		payload.Values = interaction.MessageComponentData().Values
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return false, err
+	}
+
+	secret, err := m.pg.GetOrCreateGuildSigningSecret(context.TODO(), interaction.GuildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get signing secret")
+		return false, err
+	}
+
+	rawTimestamp := strconv.FormatInt(timestamp, 10)
+
+	mac := hmac.New(sha256.New, []byte(secret.Secret))
+	mac.Write([]byte(rawTimestamp))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, action.Request.URL, bytes.NewReader(body))
+	if err != nil {
+		respondError(e, "The URL of the request is invalid.")
+		return false, nil
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Embed Generator")
+	req.Header.Set("X-Signature-Timestamp", rawTimestamp)
+	req.Header.Set("X-Signature-SHA256", signature)
+
+	resp, err := m.requestClient.Do(req)
+	if err != nil {
+		log.Debug().Err(err).Str("guild_id", interaction.GuildID).Msg("Failed to send http request")
+		respondError(e, "Failed to send request.\n\nThe server didn't respond in time or couldn't be reached.")
+		return false, nil
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxRequestResponseSize+1))
+	if err != nil {
+		respondError(e, "Failed to read the response of the request.")
+		return false, nil
+	}
+
+	if len(respBody) > maxRequestResponseSize {
+		respondError(e, fmt.Sprintf("The response of the request exceeds the maximum size of %d KB.", maxRequestResponseSize/1024))
+		return false, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respondError(e, fmt.Sprintf("The request failed with status code %d.", resp.StatusCode))
+		return false, nil
+	}
+
+	if action.Request.ExposeResponse {
+		e.templates.Set("Response", template.NewResponseData(resp.StatusCode, respBody))
+	}
+
+	return true, nil
+}
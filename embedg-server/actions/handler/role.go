@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/rs/zerolog/log"
+)
+
+// executeRoleAction adds, removes or toggles the target role or the roles that have been picked in a role select menu.
+// It returns false if the execution should be stopped.
+func (m *ActionHandler) executeRoleAction(e *actionExecution, action *actions.Action) bool {
+	s := e.s
+	i := e.i
+	interaction := e.interaction
+
+	duration := time.Duration(action.Duration) * time.Second
+	if action.Duration != 0 && (duration < time.Minute || duration > actions.MaxTemporaryRoleDuration) {
+		respondError(e, fmt.Sprintf("Temporary role duration must be between 1 minute and %d days.", actions.MaxTemporaryRoleDuration/(24*time.Hour)))
+		return false
+	}
+
+	if len(action.RoleGroup) > actions.MaxRoleGroupSize {
+		respondError(e, fmt.Sprintf("Role groups can't have more than %d roles.", actions.MaxRoleGroupSize))
+		return false
+	}
+
+	roleIDs := []string{action.TargetID}
+	if action.TargetSelected {
+		roleIDs = selectedRoleIDs(interaction)
+	}
+
+	var addedRoleIDs, removedRoleIDs []string
+	for _, roleID := range roleIDs {
+		if !e.legacyPermissions && !e.derivedPerms.CanManageRole(roleID) {
+			verb := "toggle"
+			if action.Type == actions.ActionTypeAddRole {
+				verb = "assign"
+			} else if action.Type == actions.ActionTypeRemoveRole {
+				verb = "remove"
+			}
+
+			respondError(e, fmt.Sprintf("The user that has created this message doesn't have permissions to %s the role <@&%s>.", verb, roleID))
+			return false
+		}
+
+		remove := action.Type == actions.ActionTypeRemoveRole
+		if action.Type == actions.ActionTypeToggleRole {
+			remove = slices.Contains(interaction.Member.Roles, roleID)
+		}
+
+		var err error
+		if remove {
+			err = s.GuildMemberRoleRemove(interaction.GuildID, interaction.Member.User.ID, roleID)
+			if err == nil {
+				removedRoleIDs = append(removedRoleIDs, roleID)
+				m.deleteTemporaryRole(interaction.GuildID, interaction.Member.User.ID, roleID)
+			}
+		} else {
+			err = s.GuildMemberRoleAdd(interaction.GuildID, interaction.Member.User.ID, roleID)
+			if err == nil {
+				addedRoleIDs = append(addedRoleIDs, roleID)
+				if action.Duration > 0 {
+					m.insertTemporaryRole(interaction.GuildID, interaction.Member.User.ID, roleID, duration)
+				}
+			}
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to add or remove role")
+			respondError(e, roleErrorMessage)
+			return true
+		}
+	}
+
+	if len(addedRoleIDs) != 0 && len(action.RoleGroup) != 0 {
+		// The roles of the group are exclusive, so the member loses the ones they already hold
+		for _, roleID := range action.RoleGroup {
+			if slices.Contains(addedRoleIDs, roleID) || !slices.Contains(interaction.Member.Roles, roleID) {
+				continue
+			}
+
+			if !e.legacyPermissions && !e.derivedPerms.CanManageRole(roleID) {
+				respondError(e, fmt.Sprintf("The user that has created this message doesn't have permissions to remove the role <@&%s>.", roleID))
+				return false
+			}
+
+			err := s.GuildMemberRoleRemove(interaction.GuildID, interaction.Member.User.ID, roleID)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to remove role of role group")
+				respondError(e, roleErrorMessage)
+				return true
+			}
+
+			removedRoleIDs = append(removedRoleIDs, roleID)
+			m.deleteTemporaryRole(interaction.GuildID, interaction.Member.User.ID, roleID)
+		}
+	}
+
+	if action.DisableDefaultResponse || len(addedRoleIDs)+len(removedRoleIDs) == 0 {
+		return true
+	}
+
+	var lines []string
+	if len(addedRoleIDs) != 0 {
+		line := formatRoleList("Added", addedRoleIDs)
+		if action.Duration > 0 {
+			expiresAt := time.Now().Add(duration)
+			line += fmt.Sprintf(", expires <t:%d:R>", expiresAt.Unix())
+		}
+		lines = append(lines, line)
+	}
+	if len(removedRoleIDs) != 0 {
+		lines = append(lines, formatRoleList("Removed", removedRoleIDs))
+	}
+
+	i.Respond(&discordgo.InteractionResponseData{
+		Content: strings.Join(lines, "\n"),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	return true
+}
+
+func formatRoleList(verb string, roleIDs []string) string {
+	mentions := make([]string, len(roleIDs))
+	for i, roleID := range roleIDs {
+		mentions[i] = fmt.Sprintf("<@&%s>", roleID)
+	}
+
+	if len(mentions) == 1 {
+		return fmt.Sprintf("%s role %s", verb, mentions[0])
+	}
+	return fmt.Sprintf("%s roles %s", verb, strings.Join(mentions, ", "))
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+// selectedRoleIDs returns the ids of the roles that have been picked in a role or mentionable select menu.
+func selectedRoleIDs(interaction *discordgo.Interaction) []string {
+	if interaction.Type != discordgo.InteractionMessageComponent {
+		return nil
+	}
+
+	data := interaction.MessageComponentData()
+	if data.ComponentType != discordgo.RoleSelectMenuComponent && data.ComponentType != discordgo.MentionableSelectMenuComponent {
+		return nil
+	}
+
+	res := make([]string, 0, len(data.Values))
+	for _, value := range data.Values {
+		if _, ok := data.Resolved.Roles[value]; ok {
+			res = append(res, value)
+		}
+	}
+	return res
+}
+
+// selectedChannelIDs returns the ids of the channels that have been picked in a channel select menu.
+func selectedChannelIDs(interaction *discordgo.Interaction) []string {
+	if interaction.Type != discordgo.InteractionMessageComponent {
+		return nil
+	}
+
+	data := interaction.MessageComponentData()
+	if data.ComponentType != discordgo.ChannelSelectMenuComponent {
+		return nil
+	}
+
+	return data.Values
+}
+
+// handleSelectMenuInteraction executes the action sets of all picked options in order and sends a single combined response.
+// Options that haven't been picked, but whose roles the member currently holds, execute their deselect action set instead.
+func (m *ActionHandler) handleSelectMenuInteraction(s *discordgo.Session, i Interaction) error {
+	interaction := i.Interaction()
+	data := interaction.MessageComponentData()
+
+	cols := make(map[string]*pgmodel.MessageActionSet)
+	getActionSet := func(actionSetID string) (*pgmodel.MessageActionSet, error) {
+		if col, ok := cols[actionSetID]; ok {
+			return col, nil
+		}
+
+		col, err := m.pg.Q.GetMessageActionSet(context.TODO(), pgmodel.GetMessageActionSetParams{
+			MessageID: interaction.Message.ID,
+			SetID:     actionSetID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				cols[actionSetID] = nil
+				return nil, nil
+			}
+
+			log.Error().Err(err).Msg("Failed to get message action set")
+			return nil, err
+		}
+
+		cols[actionSetID] = &col
+		return &col, nil
+	}
+
+	picked := make(map[string]bool, len(data.Values))
+	actionSetIDs := make([]string, 0, len(data.Values))
+	for _, value := range data.Values {
+		actionSetID, _, _ := strings.Cut(strings.TrimPrefix(value, "action:"), ":")
+		actionSetIDs = append(actionSetIDs, actionSetID)
+		picked[value] = true
+	}
+
+	if menu := findSelectMenu(interaction.Message.Components, data.CustomID); menu != nil && interaction.Member != nil {
+		for _, option := range menu.Options {
+			if picked[option.Value] {
+				continue
+			}
+
+			actionSetID, deselectActionSetID, _ := strings.Cut(strings.TrimPrefix(option.Value, "action:"), ":")
+			if deselectActionSetID == "" {
+				continue
+			}
+
+			col, err := getActionSet(actionSetID)
+			if err != nil {
+				return err
+			}
+			if col == nil {
+				continue
+			}
+
+			actionSet := actions.ActionSet{}
+			if err := json.Unmarshal(col.Actions, &actionSet); err != nil {
+				log.Error().Err(err).Msg("Failed to unmarshal action set")
+				continue
+			}
+
+			if actionSet.LibraryID != "" {
+				resolved, _, err := m.getLibraryActionSet(interaction.GuildID, actionSet.LibraryID)
+				if err != nil {
+					return err
+				}
+				if resolved == nil {
+					continue
+				}
+				actionSet = *resolved
+			}
+
+			if memberHoldsActionSetRoles(interaction.Member, &actionSet) {
+				actionSetIDs = append(actionSetIDs, deselectActionSetID)
+			}
+		}
+	}
+
+	bi := &BufferedInteraction{Inner: i}
+
+	var e *actionExecution
+	for _, actionSetID := range actionSetIDs {
+		col, err := getActionSet(actionSetID)
+		if err != nil {
+			return err
+		}
+		if col == nil {
+			continue
+		}
+
+		actionSet := actions.ActionSet{}
+		if e == nil {
+			actionSet, e, err = m.newActionExecution(s, bi, actionSetID, col.Actions, col.DerivedPermissions)
+			if err != nil {
+				return err
+			}
+		} else {
+			if err := json.Unmarshal(col.Actions, &actionSet); err != nil {
+				log.Error().Err(err).Msg("Failed to unmarshal action set")
+				return err
+			}
+			e.sourceID = actionSetID
+
+			// The previous action set might have come from the library and replaced the permission context of the message
+			e.derivedPerms, e.legacyPermissions, err = parseDerivedPermissions(col.DerivedPermissions)
+			if err != nil {
+				return err
+			}
+
+			if err := m.resolveLibraryActionSet(e, &actionSet); err != nil {
+				return err
+			}
+		}
+
+		if actionSet.HasLimits() {
+			ok, err := m.checkActionSetLimits(e, &actionSet)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		_, err = m.executeActions(e, actionSet.Actions, nil)
+		m.insertActionLog(e, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	bi.Flush()
+
+	if !i.HasResponded() {
+		i.Respond(nil, discordgo.InteractionResponseDeferredMessageUpdate)
+	}
+
+	return nil
+}
+
+// findSelectMenu returns the select menu with the given custom id.
+func findSelectMenu(components []discordgo.MessageComponent, customID string) *discordgo.SelectMenu {
+	for _, comp := range components {
+		row, ok := comp.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+
+		for _, comp := range row.Components {
+			if menu, ok := comp.(*discordgo.SelectMenu); ok && menu.CustomID == customID {
+				return menu
+			}
+		}
+	}
+
+	return nil
+}
+
+// memberHoldsActionSetRoles returns whether the member has all the roles that are added or toggled by the action set.
+func memberHoldsActionSetRoles(member *discordgo.Member, actionSet *actions.ActionSet) bool {
+	hasRoleAction := false
+	for _, action := range actionSet.Actions {
+		if action.Type != actions.ActionTypeAddRole && action.Type != actions.ActionTypeToggleRole {
+			continue
+		}
+
+		if action.TargetSelected || !slices.Contains(member.Roles, action.TargetID) {
+			return false
+		}
+		hasRoleAction = true
+	}
+
+	return hasRoleAction
+}
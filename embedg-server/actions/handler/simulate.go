@@ -0,0 +1,472 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/model"
+	"github.com/merlinfuchs/embed-generator/embedg-server/store"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// simulationSourceID is used as the action set id and message id of simulated interactions.
+const simulationSourceID = "simulation"
+
+type SimulationEventType string
+
+const (
+	SimulationEventResponse        SimulationEventType = "response"
+	SimulationEventFollowup        SimulationEventType = "followup"
+	SimulationEventRoleAdd         SimulationEventType = "role_add"
+	SimulationEventRoleRemove      SimulationEventType = "role_remove"
+	SimulationEventDM              SimulationEventType = "dm"
+	SimulationEventMessage         SimulationEventType = "message"
+	SimulationEventMessageEdit     SimulationEventType = "message_edit"
+	SimulationEventMessageDelete   SimulationEventType = "message_delete"
+	SimulationEventReactionAdd     SimulationEventType = "reaction_add"
+	SimulationEventMemberUpdate    SimulationEventType = "member_update"
+	SimulationEventMemberKick      SimulationEventType = "member_kick"
+	SimulationEventThreadCreate    SimulationEventType = "thread_create"
+	SimulationEventThreadMemberAdd SimulationEventType = "thread_member_add"
+	SimulationEventChannelUpdate   SimulationEventType = "channel_update"
+	SimulationEventKVSet           SimulationEventType = "kv_set"
+	SimulationEventKVIncrease      SimulationEventType = "kv_increase"
+	SimulationEventKVDelete        SimulationEventType = "kv_delete"
+	SimulationEventHTTPRequest     SimulationEventType = "http_request"
+	SimulationEventDelayedActions  SimulationEventType = "delayed_actions"
+	// SimulationEventRequest is used for all other requests to the Discord API
+	SimulationEventRequest SimulationEventType = "request"
+)
+
+// SimulationEvent is a single side effect that the actions would have had if they had been executed for real.
+type SimulationEvent struct {
+	Type      SimulationEventType `json:"type"`
+	ChannelID string              `json:"channel_id,omitempty"`
+	MessageID string              `json:"message_id,omitempty"`
+	UserID    string              `json:"user_id,omitempty"`
+	RoleID    string              `json:"role_id,omitempty"`
+	Key       string              `json:"key,omitempty"`
+	Value     string              `json:"value,omitempty"`
+	Method    string              `json:"method,omitempty"`
+	URL       string              `json:"url,omitempty"`
+	Data      json.RawMessage     `json:"data,omitempty"`
+}
+
+type SimulationParams struct {
+	GuildID   string
+	ChannelID string
+	// Member is the member that uses the component, it doesn't have to exist
+	Member *discordgo.Member
+	Locale discordgo.Locale
+	// DerivedPerms are the permissions of the user that would create the message
+	DerivedPerms actions.ActionDerivedPermissions
+	ActionSet    actions.ActionSet
+}
+
+// Simulate executes the action set as if a member had clicked a button and returns the side effects in order.
+// Requests to Discord and other servers are recorded instead of being sent and all database writes are rolled back.
+func (m *ActionHandler) Simulate(ctx context.Context, state *discordgo.State, params SimulationParams) ([]SimulationEvent, error) {
+	pg, tx, err := m.pg.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	recorder := &simulationRecorder{
+		member:     params.Member,
+		guildID:    params.GuildID,
+		dmChannels: make(map[string]string),
+	}
+
+	s, err := discordgo.New("Bot " + simulationSourceID)
+	if err != nil {
+		return nil, err
+	}
+	s.State = state
+	s.Client = &http.Client{Transport: recorder}
+
+	// The simulation has its own random source, so it doesn't advance the choices of the handler which can be seeded
+	sim := &ActionHandler{
+		pg:            pg,
+		parser:        m.parser.WithStore(pg),
+		planStore:     m.planStore,
+		bot:           &simulatedBot{session: s},
+		kvStore:       &simulatedKVStore{KVEntryStore: pg, recorder: recorder},
+		requestClient: &http.Client{Transport: recorder},
+		random:        newRandomSource(time.Now().UnixNano()),
+		recorder:      recorder,
+	}
+
+	rawActions, err := json.Marshal(params.ActionSet)
+	if err != nil {
+		return nil, err
+	}
+
+	rawDerivedPerms, err := json.Marshal(params.DerivedPerms)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &discordgo.Interaction{
+		ID:   recorder.fakeID(),
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{
+			CustomID:      "action:" + simulationSourceID,
+			ComponentType: discordgo.ButtonComponent,
+		},
+		GuildID:   params.GuildID,
+		ChannelID: params.ChannelID,
+		Member:    params.Member,
+		Locale:    params.Locale,
+		Message: &discordgo.Message{
+			ID:        simulationSourceID,
+			ChannelID: params.ChannelID,
+			GuildID:   params.GuildID,
+		},
+	}
+
+	si := &simulatedInteraction{inner: interaction, recorder: recorder}
+
+	actionSet, e, err := sim.newActionExecution(s, si, simulationSourceID, rawActions, pqtype.NullRawMessage{RawMessage: rawDerivedPerms, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if actionSet.HasLimits() {
+		ok, err := sim.checkActionSetLimits(e, &actionSet)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return recorder.events, nil
+		}
+	}
+
+	err = sim.executeActionSet(e, actionSet.Actions, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return recorder.events, nil
+}
+
+// simulationRecorder records the side effects of simulated actions.
+// It's used as the transport of the Discord session, so all REST calls of the handler end up here.
+type simulationRecorder struct {
+	sync.Mutex
+	events     []SimulationEvent
+	member     *discordgo.Member
+	guildID    string
+	dmChannels map[string]string
+	idCounter  int64
+}
+
+func (r *simulationRecorder) record(event SimulationEvent) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+// fakeID returns a unique snowflake for objects that would have been created by Discord.
+func (r *simulationRecorder) fakeID() string {
+	r.Lock()
+	defer r.Unlock()
+
+	r.idCounter++
+	return strconv.FormatInt((time.Now().UnixMilli()-1420070400000)<<22+r.idCounter, 10)
+}
+
+func (r *simulationRecorder) recordDelayedActions(actionList []actions.Action, delay time.Duration) {
+	raw, _ := json.Marshal(actions.ActionSet{Actions: actionList})
+	r.record(SimulationEvent{
+		Type:  SimulationEventDelayedActions,
+		Value: delay.String(),
+		Data:  raw,
+	})
+}
+
+func (r *simulationRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var data json.RawMessage
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if json.Valid(body) {
+			data = body
+		}
+	}
+
+	if req.URL.Host != "discord.com" {
+		r.record(SimulationEvent{
+			Type:   SimulationEventHTTPRequest,
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Data:   data,
+		})
+		return simulatedResponse(req, http.StatusOK, struct{}{})
+	}
+
+	path := strings.TrimPrefix(req.URL.EscapedPath(), "/api/v"+discordgo.APIVersion)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	match := func(pattern ...string) bool {
+		if len(parts) != len(pattern) {
+			return false
+		}
+		for i, p := range pattern {
+			if p != "*" && p != parts[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case match("guilds", "*", "members", "*", "roles", "*"):
+		eventType := SimulationEventRoleAdd
+		if req.Method == http.MethodDelete {
+			eventType = SimulationEventRoleRemove
+		}
+		r.record(SimulationEvent{Type: eventType, UserID: parts[3], RoleID: parts[5]})
+		return simulatedResponse(req, http.StatusNoContent, nil)
+	case match("guilds", "*", "members", "*"):
+		switch req.Method {
+		case http.MethodGet:
+			if r.member == nil || r.member.User == nil || r.member.User.ID != parts[3] {
+				return simulatedResponse(req, http.StatusNotFound, map[string]interface{}{
+					"code":    discordgo.ErrCodeUnknownMember,
+					"message": "Unknown Member",
+				})
+			}
+			return simulatedResponse(req, http.StatusOK, r.member)
+		case http.MethodDelete:
+			r.record(SimulationEvent{Type: SimulationEventMemberKick, UserID: parts[3], Value: req.URL.Query().Get("reason")})
+			return simulatedResponse(req, http.StatusNoContent, nil)
+		default:
+			r.record(SimulationEvent{Type: SimulationEventMemberUpdate, UserID: parts[3], Data: data})
+			return simulatedResponse(req, http.StatusOK, r.member)
+		}
+	case match("users", "@me", "channels"):
+		var payload struct {
+			RecipientID string `json:"recipient_id"`
+		}
+		json.Unmarshal(data, &payload)
+
+		channelID := r.fakeID()
+		r.Lock()
+		r.dmChannels[channelID] = payload.RecipientID
+		r.Unlock()
+
+		return simulatedResponse(req, http.StatusOK, &discordgo.Channel{
+			ID:         channelID,
+			Type:       discordgo.ChannelTypeDM,
+			Recipients: []*discordgo.User{{ID: payload.RecipientID}},
+		})
+	case match("channels", "*", "messages"):
+		r.Lock()
+		userID, isDM := r.dmChannels[parts[1]]
+		r.Unlock()
+
+		messageID := r.fakeID()
+		if isDM {
+			r.record(SimulationEvent{Type: SimulationEventDM, UserID: userID, Data: data})
+		} else {
+			r.record(SimulationEvent{Type: SimulationEventMessage, ChannelID: parts[1], MessageID: messageID, Data: data})
+		}
+		return simulatedResponse(req, http.StatusOK, &discordgo.Message{ID: messageID, ChannelID: parts[1]})
+	case match("channels", "*", "messages", "*"):
+		if req.Method == http.MethodDelete {
+			r.record(SimulationEvent{Type: SimulationEventMessageDelete, ChannelID: parts[1], MessageID: parts[3]})
+			return simulatedResponse(req, http.StatusNoContent, nil)
+		}
+		if req.Method != http.MethodGet {
+			r.record(SimulationEvent{Type: SimulationEventMessageEdit, ChannelID: parts[1], MessageID: parts[3], Data: data})
+		}
+		return simulatedResponse(req, http.StatusOK, &discordgo.Message{ID: parts[3], ChannelID: parts[1]})
+	case match("channels", "*", "messages", "*", "reactions", "*", "@me"):
+		emoji, _ := url.PathUnescape(parts[5])
+		r.record(SimulationEvent{Type: SimulationEventReactionAdd, ChannelID: parts[1], MessageID: parts[3], Value: emoji})
+		return simulatedResponse(req, http.StatusNoContent, nil)
+	case match("channels", "*", "threads"), match("channels", "*", "messages", "*", "threads"):
+		threadID := r.fakeID()
+		r.record(SimulationEvent{Type: SimulationEventThreadCreate, ChannelID: parts[1], Data: data})
+		return simulatedResponse(req, http.StatusOK, &discordgo.Channel{
+			ID:       threadID,
+			GuildID:  r.guildID,
+			ParentID: parts[1],
+			Type:     discordgo.ChannelTypeGuildPublicThread,
+		})
+	case match("channels", "*", "thread-members", "*"):
+		r.record(SimulationEvent{Type: SimulationEventThreadMemberAdd, ChannelID: parts[1], UserID: parts[3]})
+		return simulatedResponse(req, http.StatusNoContent, nil)
+	case match("channels", "*"):
+		if req.Method != http.MethodGet {
+			r.record(SimulationEvent{Type: SimulationEventChannelUpdate, ChannelID: parts[1], Data: data})
+		}
+		return simulatedResponse(req, http.StatusOK, &discordgo.Channel{ID: parts[1], GuildID: r.guildID})
+	}
+
+	r.record(SimulationEvent{
+		Type:   SimulationEventRequest,
+		Method: req.Method,
+		URL:    path,
+		Data:   data,
+	})
+	return simulatedResponse(req, http.StatusOK, struct{}{})
+}
+
+func simulatedResponse(req *http.Request, status int, body interface{}) (*http.Response, error) {
+	var raw []byte
+	if body != nil {
+		var err error
+		raw, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(raw)),
+		ContentLength: int64(len(raw)),
+		Request:       req,
+	}, nil
+}
+
+// simulatedInteraction records the responses instead of sending them to Discord.
+type simulatedInteraction struct {
+	inner     *discordgo.Interaction
+	recorder  *simulationRecorder
+	responded bool
+}
+
+func (i *simulatedInteraction) Interaction() *discordgo.Interaction {
+	return i.inner
+}
+
+func (i *simulatedInteraction) HasResponded() bool {
+	return i.responded
+}
+
+func (i *simulatedInteraction) Respond(data *discordgo.InteractionResponseData, t ...discordgo.InteractionResponseType) *discordgo.Message {
+	responseType := discordgo.InteractionResponseChannelMessageWithSource
+	if len(t) > 0 {
+		responseType = t[0]
+	}
+
+	raw, _ := json.Marshal(&discordgo.InteractionResponse{
+		Type: responseType,
+		Data: data,
+	})
+
+	eventType := SimulationEventResponse
+	if i.responded {
+		eventType = SimulationEventFollowup
+	}
+	i.recorder.record(SimulationEvent{Type: eventType, ChannelID: i.inner.ChannelID, Data: raw})
+
+	var msg *discordgo.Message
+	if i.responded {
+		msg = &discordgo.Message{ID: i.recorder.fakeID(), ChannelID: i.inner.ChannelID}
+	}
+
+	i.responded = true
+	return msg
+}
+
+// simulatedBot sends all messages through the recording session of the simulation.
+type simulatedBot struct {
+	session *discordgo.Session
+}
+
+func (b *simulatedBot) GetSessionForGuild(ctx context.Context, guildID string) (*discordgo.Session, error) {
+	return b.session, nil
+}
+
+func (b *simulatedBot) SendMessageToChannel(ctx context.Context, channelID string, params *discordgo.WebhookParams) (*discordgo.Message, error) {
+	return b.session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content:         params.Content,
+		Embeds:          params.Embeds,
+		TTS:             params.TTS,
+		Components:      params.Components,
+		AllowedMentions: params.AllowedMentions,
+	}, discordgo.WithContext(ctx))
+}
+
+func (b *simulatedBot) EditMessageInChannel(ctx context.Context, channelID string, messageID string, params *discordgo.WebhookEdit) (*discordgo.Message, error) {
+	return b.session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Channel:         channelID,
+		ID:              messageID,
+		Content:         params.Content,
+		Embeds:          params.Embeds,
+		Components:      params.Components,
+		AllowedMentions: params.AllowedMentions,
+	}, discordgo.WithContext(ctx))
+}
+
+func (b *simulatedBot) AddReactionsToMessage(ctx context.Context, channelID string, messageID string, reactions []actions.ReactionWithActions) error {
+	for _, reaction := range reactions {
+		if reaction.Emoji == nil {
+			continue
+		}
+
+		err := b.session.MessageReactionAdd(channelID, messageID, reaction.APIName(), discordgo.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// simulatedKVStore records all writes, the writes themselves end up in the transaction of the simulation.
+type simulatedKVStore struct {
+	store.KVEntryStore
+	recorder *simulationRecorder
+}
+
+func (s *simulatedKVStore) SetKVEntry(ctx context.Context, entry model.KVEntry) error {
+	err := s.KVEntryStore.SetKVEntry(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	s.recorder.record(SimulationEvent{Type: SimulationEventKVSet, Key: entry.Key, Value: entry.Value})
+	return nil
+}
+
+func (s *simulatedKVStore) IncreaseKVEntry(ctx context.Context, params model.KVEntryIncreaseParams) (model.KVEntry, error) {
+	entry, err := s.KVEntryStore.IncreaseKVEntry(ctx, params)
+	if err != nil {
+		return entry, err
+	}
+
+	s.recorder.record(SimulationEvent{Type: SimulationEventKVIncrease, Key: entry.Key, Value: entry.Value})
+	return entry, nil
+}
+
+func (s *simulatedKVStore) DeleteKVEntry(ctx context.Context, guildID string, key string) (model.KVEntry, error) {
+	entry, err := s.KVEntryStore.DeleteKVEntry(ctx, guildID, key)
+	if err != nil {
+		return entry, err
+	}
+
+	s.recorder.record(SimulationEvent{Type: SimulationEventKVDelete, Key: entry.Key, Value: entry.Value})
+	return entry, nil
+}
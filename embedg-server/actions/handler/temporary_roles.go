@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+// insertTemporaryRole makes sure the role is removed from the member once the duration has passed.
+// Adding the same role again extends the existing grant.
+func (m *ActionHandler) insertTemporaryRole(guildID string, userID string, roleID string, duration time.Duration) {
+	_, err := m.pg.Q.UpsertTemporaryRole(context.TODO(), pgmodel.UpsertTemporaryRoleParams{
+		ID:        util.UniqueID(),
+		GuildID:   guildID,
+		UserID:    userID,
+		RoleID:    roleID,
+		ExpiresAt: time.Now().UTC().Add(duration),
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to insert temporary role")
+	}
+}
+
+// deleteTemporaryRole forgets about the grant after the role has been removed from the member by an action.
+func (m *ActionHandler) deleteTemporaryRole(guildID string, userID string, roleID string) {
+	err := m.pg.Q.DeleteTemporaryRoleForMember(context.TODO(), pgmodel.DeleteTemporaryRoleForMemberParams{
+		GuildID: guildID,
+		UserID:  userID,
+		RoleID:  roleID,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete temporary role")
+	}
+}
+
+// temporaryRolesBatchSize is the maximum number of expired temporary roles that are removed at once, the rest waits for the next run.
+const temporaryRolesBatchSize = 100
+
+func (m *ActionHandler) lazyRemoveTemporaryRolesTask() {
+	for {
+		time.Sleep(time.Minute)
+
+		if err := m.removeExpiredTemporaryRoles(); err != nil {
+			log.Error().Err(err).Msg("Failed to remove expired temporary roles")
+		}
+	}
+}
+
+func (m *ActionHandler) removeExpiredTemporaryRoles() error {
+	temporaryRoles, err := m.pg.Q.GetExpiredTemporaryRoles(context.Background(), pgmodel.GetExpiredTemporaryRolesParams{
+		ExpiresAt: time.Now().UTC(),
+		Limit:     temporaryRolesBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, temporaryRole := range temporaryRoles {
+		// The grant is deleted first to make sure the role isn't removed twice
+		_, err := m.pg.Q.DeleteTemporaryRole(context.Background(), pgmodel.DeleteTemporaryRoleParams{
+			ID:      temporaryRole.ID,
+			GuildID: temporaryRole.GuildID,
+		})
+		if err != nil {
+			if err != sql.ErrNoRows {
+				log.Error().Err(err).Msg("Failed to delete temporary role")
+			}
+			continue
+		}
+
+		s, err := m.bot.GetSessionForGuild(context.Background(), temporaryRole.GuildID)
+		if err != nil {
+			log.Error().Err(err).Str("guild_id", temporaryRole.GuildID).Msg("Failed to get session for temporary role")
+			continue
+		}
+
+		err = s.GuildMemberRoleRemove(temporaryRole.GuildID, temporaryRole.UserID, temporaryRole.RoleID)
+		if err != nil {
+			if util.IsDiscordRestErrorCode(err, discordgo.ErrCodeUnknownMember, discordgo.ErrCodeUnknownRole) {
+				continue
+			}
+
+			log.Error().Err(err).Str("guild_id", temporaryRole.GuildID).Msg("Failed to remove temporary role")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/rs/zerolog/log"
+)
+
+// threadAutoArchiveDuration is the time in minutes after which inactive threads are archived.
+const threadAutoArchiveDuration = 10080
+
+// createThread creates a thread, adds the member to it and posts the saved message inside of it.
+// The thread is created from the message of the interaction if it's public and no channel is configured.
+func (m *ActionHandler) createThread(e *actionExecution, action *actions.Action) (bool, error) {
+	s := e.s
+	i := e.i
+	interaction := e.interaction
+
+	if e.legacyPermissions {
+		return true, nil
+	}
+
+	channelID := action.ChannelID
+	if channelID == "" {
+		channelID = interaction.ChannelID
+	}
+
+	requiredPerms := int64(discordgo.PermissionCreatePrivateThreads)
+	if action.Public {
+		requiredPerms = discordgo.PermissionCreatePublicThreads
+	}
+
+	perms, err := m.parser.DerivePermissionsForActions(e.derivedPerms.UserID, interaction.GuildID, channelID)
+	if err != nil || !perms.HasChannelPermission(requiredPerms) {
+		respondError(e, fmt.Sprintf("The user that has created this message doesn't have permissions to create threads in the channel <#%s>.", channelID))
+		return false, nil
+	}
+
+	name, ok := executeTemplate(e, e.variables.FillString(action.Text))
+	if !ok {
+		return false, nil
+	}
+	if name == "" {
+		name = interaction.Member.User.Username
+	}
+	if runes := []rune(name); len(runes) > 100 {
+		name = string(runes[:100])
+	}
+
+	var thread *discordgo.Channel
+	if action.Public && action.ChannelID == "" && interaction.Message != nil {
+		thread, err = s.MessageThreadStartComplex(channelID, interaction.Message.ID, &discordgo.ThreadStart{
+			Name:                name,
+			AutoArchiveDuration: threadAutoArchiveDuration,
+		})
+	} else {
+		threadType := discordgo.ChannelTypeGuildPrivateThread
+		if action.Public {
+			threadType = discordgo.ChannelTypeGuildPublicThread
+		}
+
+		thread, err = s.ThreadStartComplex(channelID, &discordgo.ThreadStart{
+			Name:                name,
+			AutoArchiveDuration: threadAutoArchiveDuration,
+			Type:                threadType,
+			Invitable:           false,
+		})
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create thread")
+		respondError(e, "Failed to create thread.\n\nPlease make sure that the bot has permissions to create threads in the channel.")
+		return false, nil
+	}
+
+	err = s.ThreadMemberAdd(thread.ID, interaction.Member.User.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to add member to thread")
+	}
+
+	if action.TargetID != "" {
+		data, ok, err := m.prepareSavedMessage(e, action.TargetID)
+		if err != nil || !ok {
+			return false, err
+		}
+
+		components, err := m.parser.ParseMessageComponents(data.Components)
+		if err != nil {
+			return false, fmt.Errorf("Invalid actions: %w", err)
+		}
+
+		newMsg, err := s.ChannelMessageSendComplex(thread.ID, &discordgo.MessageSend{
+			Content:         data.Content,
+			Embeds:          data.Embeds,
+			TTS:             data.TTS,
+			Components:      components,
+			AllowedMentions: data.AllowedMentions,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to send message to thread")
+		} else {
+			err = m.createActionsAndReactions(e, data, perms, newMsg)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if !action.DisableDefaultResponse {
+		i.Respond(&discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Created thread <#%s>", thread.ID),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	return true, nil
+}
+
+// closeThread locks and archives the thread that the interaction was created in.
+func (m *ActionHandler) closeThread(e *actionExecution, action *actions.Action) (bool, error) {
+	s := e.s
+	i := e.i
+	interaction := e.interaction
+
+	if e.legacyPermissions {
+		return true, nil
+	}
+
+	thread, err := s.State.Channel(interaction.ChannelID)
+	if err != nil {
+		thread, err = s.Channel(interaction.ChannelID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !thread.IsThread() {
+		respondError(e, "This can only be used inside of a thread.")
+		return false, nil
+	}
+
+	perms, err := m.parser.DerivePermissionsForActions(e.derivedPerms.UserID, interaction.GuildID, thread.ParentID)
+	if err != nil || !perms.HasChannelPermission(discordgo.PermissionManageThreads) {
+		respondError(e, fmt.Sprintf("The user that has created this message doesn't have permissions to manage threads in the channel <#%s>.", thread.ParentID))
+		return false, nil
+	}
+
+	// Responding isn't possible anymore once the thread has been archived
+	if !action.DisableDefaultResponse {
+		i.Respond(&discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Thread has been closed by <@%s>.", interaction.Member.User.ID),
+		})
+	} else {
+		respondDefault(e)
+	}
+
+	locked := true
+	archived := true
+	_, err = s.ChannelEditComplex(thread.ID, &discordgo.ChannelEdit{
+		Locked:   &locked,
+		Archived: &archived,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to close thread")
+		respondError(e, "Failed to close thread.\n\nPlease make sure that the bot has the manage threads permission.")
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -2,8 +2,10 @@ package parser
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
 	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
@@ -65,6 +67,82 @@ func (m *ActionParser) RetrieveActionsForMessage(ctx context.Context, messageID
 	return res, nil
 }
 
+// CreateReactionsForMessage replaces the reactions of the message that execute action sets.
+func (m *ActionParser) CreateReactionsForMessage(ctx context.Context, reactions []actions.ReactionWithActions, messageID string) error {
+	err := m.pg.Q.DeleteMessageReactionsForMessage(ctx, messageID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete message reactions")
+	}
+
+	if len(reactions) > actions.MaxMessageReactions {
+		reactions = reactions[:actions.MaxMessageReactions]
+	}
+
+	for _, reaction := range reactions {
+		if reaction.Emoji == nil || reaction.ActionSetID == "" {
+			continue
+		}
+
+		err = m.pg.Q.InsertMessageReaction(ctx, pgmodel.InsertMessageReactionParams{
+			MessageID: messageID,
+			Emoji:     actions.ReactionEmojiKey(reaction.Emoji.ID, reaction.Emoji.Name),
+			SetID:     reaction.ActionSetID,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to insert message reaction")
+		}
+	}
+	return nil
+}
+
+// CreatePollForMessage creates the poll of the message if any of its actions is a poll vote.
+// The closing time and results message are taken from the first vote action that has them configured.
+func (m *ActionParser) CreatePollForMessage(ctx context.Context, actionSets map[string]actions.ActionSet, guildID string, channelID string, messageID string) error {
+	hasPoll := false
+	var closesAt sql.NullTime
+	var resultsMessageID sql.NullString
+
+	var findPolls func(actionList []actions.Action)
+	findPolls = func(actionList []actions.Action) {
+		for _, action := range actionList {
+			if action.Type == actions.ActionTypePollVote && action.Poll != nil {
+				hasPoll = true
+				if action.Poll.ClosesAt != nil && !closesAt.Valid {
+					closesAt = sql.NullTime{Time: action.Poll.ClosesAt.UTC(), Valid: true}
+				}
+				if action.Poll.ResultsMessageID != "" && !resultsMessageID.Valid {
+					resultsMessageID = sql.NullString{String: action.Poll.ResultsMessageID, Valid: true}
+				}
+			}
+
+			for _, nested := range action.NestedActionSets() {
+				findPolls(nested.Actions)
+			}
+		}
+	}
+
+	for _, actionSet := range actionSets {
+		findPolls(actionSet.Actions)
+	}
+
+	if !hasPoll {
+		return nil
+	}
+
+	err := m.pg.Q.InsertPoll(ctx, pgmodel.InsertPollParams{
+		MessageID:        messageID,
+		GuildID:          guildID,
+		ChannelID:        channelID,
+		ResultsMessageID: resultsMessageID,
+		ClosesAt:         closesAt,
+		CreatedAt:        time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to insert poll: %w", err)
+	}
+	return nil
+}
+
 func (m *ActionParser) DeleteActionsForMessage(messageID string) error {
 	return nil
 }
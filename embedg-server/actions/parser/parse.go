@@ -24,6 +24,15 @@ func New(accessManager *access.AccessManager, pg *postgres.PostgresStore, state
 	}
 }
 
+// WithStore returns a copy of the parser that uses the given store, e.g. to run queries inside of a transaction.
+func (m *ActionParser) WithStore(pg *postgres.PostgresStore) *ActionParser {
+	return &ActionParser{
+		accessManager: m.accessManager,
+		pg:            pg,
+		state:         m.state,
+	}
+}
+
 func (m *ActionParser) ParseMessageComponents(data []actions.ActionRowWithActions) ([]discordgo.MessageComponent, error) {
 	components := make([]discordgo.MessageComponent, len(data))
 
@@ -54,9 +63,14 @@ func (m *ActionParser) ParseMessageComponents(data []actions.ActionRowWithAction
 			} else if component.Type == discordgo.SelectMenuComponent {
 				options := make([]discordgo.SelectMenuOption, len(component.Options))
 				for x, option := range component.Options {
+					value := "action:" + option.ActionSetID
+					if option.DeselectActionSetID != "" {
+						value += ":" + option.DeselectActionSetID
+					}
+
 					options[x] = discordgo.SelectMenuOption{
 						Label:       option.Label,
-						Value:       "action:" + option.ActionSetID,
+						Value:       value,
 						Description: option.Description,
 						Default:     option.Default,
 						Emoji:       option.Emoji,
@@ -72,6 +86,16 @@ func (m *ActionParser) ParseMessageComponents(data []actions.ActionRowWithAction
 					Options:     options,
 					Disabled:    component.Disabled,
 				}
+			} else if component.IsAutoPopulatedSelectMenu() {
+				ar.Components[y] = discordgo.SelectMenu{
+					MenuType:     discordgo.SelectMenuType(component.Type),
+					CustomID:     "action:" + component.ActionSetID,
+					Placeholder:  component.Placeholder,
+					MinValues:    component.MinValues,
+					MaxValues:    component.MaxValues,
+					Disabled:     component.Disabled,
+					ChannelTypes: component.ChannelTypes,
+				}
 			}
 		}
 
@@ -107,14 +131,30 @@ func (m *ActionParser) UnparseMessageComponents(data []discordgo.MessageComponen
 					ActionSetID: strings.TrimPrefix(c.CustomID, "action:"),
 				})
 			case *discordgo.SelectMenu:
+				if c.MenuType != 0 && c.MenuType != discordgo.StringSelectMenu {
+					ar.Components = append(ar.Components, actions.ComponentWithActions{
+						Type:         discordgo.ComponentType(c.MenuType),
+						Disabled:     c.Disabled,
+						Placeholder:  c.Placeholder,
+						MinValues:    c.MinValues,
+						MaxValues:    c.MaxValues,
+						ChannelTypes: c.ChannelTypes,
+						ActionSetID:  strings.TrimPrefix(c.CustomID, "action:"),
+					})
+					continue
+				}
+
 				options := make([]actions.ComponentSelectOptionWithActions, 0, len(c.Options))
 				for _, option := range c.Options {
+					actionSetID, deselectActionSetID, _ := strings.Cut(strings.TrimPrefix(option.Value, "action:"), ":")
+
 					options = append(options, actions.ComponentSelectOptionWithActions{
-						Label:       option.Label,
-						Description: option.Description,
-						Emoji:       option.Emoji,
-						Default:     option.Default,
-						ActionSetID: strings.TrimPrefix(option.Value, "action:"),
+						Label:               option.Label,
+						Description:         option.Description,
+						Emoji:               option.Emoji,
+						Default:             option.Default,
+						ActionSetID:         actionSetID,
+						DeselectActionSetID: deselectActionSetID,
 					})
 				}
 
@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/merlinfuchs/discordgo"
 	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
@@ -72,62 +75,320 @@ func (m *ActionParser) CheckPermissionsForActionSets(actionSets map[string]actio
 		permissions = channelAccess.UserPermissions
 	}
 
-	var checkActions func(actionSets map[string]actions.ActionSet, nestingLevel int) error
+	var checkActions func(actionList []actions.Action, nestingLevel int, delayed bool) error
 
-	checkActions = func(actionSets map[string]actions.ActionSet, nestingLevel int) error {
+	checkSavedMessage := func(messageID string, nestingLevel int) error {
+		msg, err := m.pg.Q.GetSavedMessageForGuild(context.TODO(), pgmodel.GetSavedMessageForGuildParams{
+			GuildID: sql.NullString{Valid: true, String: guildID},
+			ID:      messageID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("Saved message %s does not exist or belongs to a different server", messageID)
+			}
+			return err
+		}
+
+		data := &actions.MessageWithActions{}
+		err = json.Unmarshal(msg.Data, data)
+		if err != nil {
+			return err
+		}
+
+		for _, actionSet := range data.Actions {
+			if err := checkActionSetLimits(actionSet); err != nil {
+				return err
+			}
+
+			if err := checkActions(actionSet.Actions, nestingLevel+1, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	checkChannel := func(targetChannelID string, requiredPerms int64) error {
+		targetChannel, err := m.state.Channel(targetChannelID)
+		if err != nil {
+			if err == discordgo.ErrStateNotFound {
+				return fmt.Errorf("Channel %s does not exist", targetChannelID)
+			}
+			return err
+		}
+
+		if targetChannel.GuildID != guildID {
+			return fmt.Errorf("Channel %s does not belong to guild %s", targetChannelID, guildID)
+		}
+
+		ca, err := m.accessManager.GetChannelAccessForUser(userID, targetChannelID)
+		if err != nil {
+			return err
+		}
+
+		if ca.UserPermissions&(requiredPerms|discordgo.PermissionAdministrator) == 0 {
+			return fmt.Errorf("You have no access to the channel %s", targetChannelID)
+		}
+		return nil
+	}
+
+	checkRole := func(roleID string) error {
+		role, err := m.state.Role(guildID, roleID)
+		if err != nil {
+			if err == discordgo.ErrStateNotFound {
+				return fmt.Errorf("Role %s does not exist", roleID)
+			}
+			return err
+		}
+
+		if !memberIsOwner && role.Position >= highestRolePosition {
+			return fmt.Errorf("You can not assign the role %s", roleID)
+		}
+		return nil
+	}
+
+	checkActions = func(actionList []actions.Action, nestingLevel int, delayed bool) error {
 		if nestingLevel > 5 {
-			return fmt.Errorf("You can't nest more than 5 saved messages with actions")
+			return fmt.Errorf("You can't nest more than 5 levels of actions or saved messages with actions")
 		}
 
-		for _, actionSet := range actionSets {
-			for _, action := range actionSet.Actions {
-				switch action.Type {
-				case actions.ActionTypeTextResponse, actions.ActionTypeTextDM, actions.ActionTypeTextEdit:
-					break
-				case actions.ActionTypeAddRole, actions.ActionTypeRemoveRole, actions.ActionTypeToggleRole:
-					if permissions&discordgo.PermissionManageRoles == 0 {
-						return fmt.Errorf("You have no permission to manage roles in the channel %s", channelID)
+		for _, action := range actionList {
+			if delayed && action.RespondsToInteraction() {
+				return fmt.Errorf("Actions that respond to the interaction or edit its message can't be used after a wait")
+			}
+
+			switch action.Type {
+			case actions.ActionTypeTextResponse, actions.ActionTypeTextDM, actions.ActionTypeTextEdit,
+				actions.ActionTypeDeleteMessage, actions.ActionTypeDisableComponents:
+				break
+			case actions.ActionTypeAddRole, actions.ActionTypeRemoveRole, actions.ActionTypeToggleRole:
+				if permissions&discordgo.PermissionManageRoles == 0 {
+					return fmt.Errorf("You have no permission to manage roles in the channel %s", channelID)
+				}
+
+				if action.Duration != 0 {
+					duration := time.Duration(action.Duration) * time.Second
+					if action.Type == actions.ActionTypeRemoveRole {
+						return fmt.Errorf("Only roles that are added can be temporary")
+					}
+					if duration < time.Minute || duration > actions.MaxTemporaryRoleDuration {
+						return fmt.Errorf("Temporary role duration must be between 1 minute and %d days", actions.MaxTemporaryRoleDuration/(24*time.Hour))
+					}
+				}
+
+				if len(action.RoleGroup) != 0 {
+					if action.Type == actions.ActionTypeRemoveRole {
+						return fmt.Errorf("Only roles that are added can have a role group")
+					}
+					if len(action.RoleGroup) > actions.MaxRoleGroupSize {
+						return fmt.Errorf("Role groups can't have more than %d roles", actions.MaxRoleGroupSize)
 					}
 
-					role, err := m.state.Role(guildID, action.TargetID)
-					if err != nil {
-						if err == discordgo.ErrStateNotFound {
-							return fmt.Errorf("Role %s does not exist", action.TargetID)
+					for _, roleID := range action.RoleGroup {
+						if err := checkRole(roleID); err != nil {
+							return err
 						}
+					}
+				}
+
+				// The picked roles are checked against the derived permissions when the action is executed
+				if action.TargetSelected {
+					break
+				}
+
+				if err := checkRole(action.TargetID); err != nil {
+					return err
+				}
+				break
+			case actions.ActionTypeSavedMessageResponse, actions.ActionTypeSavedMessageDM, actions.ActionTypeSavedMessageEdit, actions.ActionTypeSavedMessageChannel:
+				if action.Type == actions.ActionTypeSavedMessageChannel && !action.TargetSelected {
+					if err := checkChannel(action.ChannelID, discordgo.PermissionManageWebhooks); err != nil {
 						return err
 					}
+				}
 
-					if !memberIsOwner && role.Position >= highestRolePosition {
-						return fmt.Errorf("You can not assign the role %s", action.TargetID)
+				if err := checkSavedMessage(action.TargetID, nestingLevel); err != nil {
+					return err
+				}
+			case actions.ActionTypeCreateThread:
+				requiredPerms := int64(discordgo.PermissionCreatePrivateThreads)
+				if action.Public {
+					requiredPerms = discordgo.PermissionCreatePublicThreads
+				}
+
+				if action.ChannelID != "" {
+					if err := checkChannel(action.ChannelID, requiredPerms); err != nil {
+						return err
 					}
-					break
-				case actions.ActionTypeSavedMessageResponse, actions.ActionTypeSavedMessageDM, actions.ActionTypeSavedMessageEdit:
-					msg, err := m.pg.Q.GetSavedMessageForGuild(context.TODO(), pgmodel.GetSavedMessageForGuildParams{
-						GuildID: sql.NullString{Valid: true, String: guildID},
-						ID:      action.TargetID,
-					})
-					if err != nil {
-						if err == sql.ErrNoRows {
-							return fmt.Errorf("Saved message %s does not exist or belongs to a different server", action.TargetID)
-						}
+				} else if permissions&(requiredPerms|discordgo.PermissionAdministrator) == 0 {
+					return fmt.Errorf("You have no permission to create threads in the channel %s", channelID)
+				}
+
+				if action.TargetID != "" {
+					if err := checkSavedMessage(action.TargetID, nestingLevel); err != nil {
 						return err
 					}
+				}
+			case actions.ActionTypeCloseThread:
+				if permissions&(discordgo.PermissionManageThreads|discordgo.PermissionAdministrator) == 0 {
+					return fmt.Errorf("You have no permission to manage threads in the channel %s", channelID)
+				}
+			case actions.ActionTypeModalForm:
+				if action.Form == nil {
+					return fmt.Errorf("Forms must have at least one field")
+				}
+
+				if err := action.Form.Validate(); err != nil {
+					return err
+				}
+			case actions.ActionTypeConditional:
+				if action.Condition == nil || strings.TrimSpace(action.Condition.Expression) == "" {
+					return fmt.Errorf("Conditions must have an expression")
+				}
+			case actions.ActionTypeRandomChoice:
+				if len(action.Choices) == 0 {
+					return fmt.Errorf("Random choices must have at least one choice")
+				}
+
+				if len(action.Choices) > actions.MaxChoices {
+					return fmt.Errorf("Random choices can't have more than %d choices", actions.MaxChoices)
+				}
 
-					data := &actions.MessageWithActions{}
-					err = json.Unmarshal(msg.Data, data)
-					if err != nil {
+				for _, choice := range action.Choices {
+					if choice.Weight < 1 {
+						return fmt.Errorf("The weight of choices must be at least 1")
+					}
+				}
+			case actions.ActionTypeSetNickname, actions.ActionTypeTimeout, actions.ActionTypeKick:
+				requiredPerms := int64(discordgo.PermissionManageNicknames)
+				if action.Type == actions.ActionTypeTimeout {
+					requiredPerms = discordgo.PermissionModerateMembers
+				} else if action.Type == actions.ActionTypeKick {
+					requiredPerms = discordgo.PermissionKickMembers
+				}
+
+				if !memberIsOwner && permissions&(requiredPerms|discordgo.PermissionAdministrator) == 0 {
+					return fmt.Errorf("You have no permission to moderate members in the channel %s", channelID)
+				}
+
+				if action.Type == actions.ActionTypeTimeout {
+					duration := time.Duration(action.Duration) * time.Second
+					if duration <= 0 || duration > actions.MaxTimeoutDuration {
+						return fmt.Errorf("Timeout duration must be between 1 second and %d days", actions.MaxTimeoutDuration/(24*time.Hour))
+					}
+				}
+			case actions.ActionTypePollVote:
+				if action.Poll == nil || strings.TrimSpace(action.Poll.Option) == "" {
+					return fmt.Errorf("Poll votes must have an option")
+				}
+
+				if action.TargetID != "" {
+					if err := checkSavedMessage(action.TargetID, nestingLevel); err != nil {
+						return err
+					}
+				}
+				if action.Poll.ResultsMessageID != "" {
+					if err := checkSavedMessage(action.Poll.ResultsMessageID, nestingLevel); err != nil {
 						return err
 					}
+				}
+			case actions.ActionTypeHTTPRequest:
+				if action.Request == nil {
+					return fmt.Errorf("HTTP requests must have a URL")
+				}
+
+				u, err := url.Parse(action.Request.URL)
+				if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					return fmt.Errorf("HTTP requests must have a valid http or https URL")
+				}
+
+				if len(action.Request.Fields) > actions.MaxRequestFields {
+					return fmt.Errorf("HTTP requests can't have more than %d fields", actions.MaxRequestFields)
+				}
+
+				for _, field := range action.Request.Fields {
+					if field.Name == "" {
+						return fmt.Errorf("HTTP request fields must have a name")
+					}
+				}
+			case actions.ActionTypeWait:
+				duration := time.Duration(action.Duration) * time.Second
+				if duration <= 0 || duration > actions.MaxWaitDuration {
+					return fmt.Errorf("Wait duration must be between 1 second and %d days", actions.MaxWaitDuration/(24*time.Hour))
+				}
+			}
 
-					return checkActions(data.Actions, nestingLevel+1)
+			for _, actionSet := range action.NestedActionSets() {
+				if err := checkActions(actionSet.Actions, nestingLevel+1, delayed); err != nil {
+					return err
 				}
 			}
+
+			if action.DelaysExecution() {
+				delayed = true
+			}
 		}
 
 		return nil
 	}
 
-	return checkActions(actionSets, 0)
+	for _, actionSet := range actionSets {
+		if err := checkActionSetLimits(actionSet); err != nil {
+			return err
+		}
+
+		if err := checkActions(actionSet.Actions, 0, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckLibraryActionSets makes sure that the library entries referenced by the action sets exist in the guild
+// and that the user is allowed to use their actions, as if the actions had been added to the message directly.
+func (m *ActionParser) CheckLibraryActionSets(actionSets map[string]actions.ActionSet, userID string, guildID string, channelID string) error {
+	librarySets := make(map[string]actions.ActionSet)
+	for id, actionSet := range actionSets {
+		if actionSet.LibraryID == "" {
+			continue
+		}
+
+		if err := checkActionSetLimits(actionSet); err != nil {
+			return err
+		}
+
+		row, err := m.pg.Q.GetGuildActionSet(context.TODO(), pgmodel.GetGuildActionSetParams{
+			ID:      actionSet.LibraryID,
+			GuildID: guildID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("Action set %s does not exist in the library of this server", actionSet.LibraryID)
+			}
+			return err
+		}
+
+		resolved := actions.ActionSet{}
+		if err := json.Unmarshal(row.Actions, &resolved); err != nil {
+			return err
+		}
+
+		librarySets[id] = resolved
+	}
+
+	if len(librarySets) == 0 {
+		return nil
+	}
+
+	return m.CheckPermissionsForActionSets(librarySets, userID, guildID, channelID)
+}
+
+func checkActionSetLimits(actionSet actions.ActionSet) error {
+	if actionSet.Cooldown < 0 || actionSet.MaxUsesPerUser < 0 || actionSet.MaxUses < 0 {
+		return fmt.Errorf("Cooldowns and usage limits can't be negative")
+	}
+
+	return nil
 }
 
 func (m *ActionParser) DerivePermissionsForActions(userID string, guildID string, channelID string) (actions.ActionDerivedPermissions, error) {
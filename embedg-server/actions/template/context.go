@@ -22,6 +22,8 @@ type TemplateContext struct {
 	data  map[string]interface{}
 	funcs map[string]interface{}
 
+	snippets *SnippetProvider
+
 	MaxOps    int
 	MaxOutput int64
 }
@@ -33,9 +35,14 @@ func NewContext(name string, maxOps int, providers ...ContextProvider) *Template
 	funcs := make(map[string]interface{}, len(standardFuncMap))
 	maps.Copy(funcs, standardFuncMap)
 
+	var snippets *SnippetProvider
 	for _, provider := range providers {
 		provider.ProvideData(data)
 		provider.ProvideFuncs(funcs)
+
+		if p, ok := provider.(*SnippetProvider); ok {
+			snippets = p
+		}
 	}
 
 	if maxOps == 0 {
@@ -47,6 +54,8 @@ func NewContext(name string, maxOps int, providers ...ContextProvider) *Template
 		data:  data,
 		funcs: funcs,
 
+		snippets: snippets,
+
 		MaxOps:    maxOps,
 		MaxOutput: DefaultMaxOutput,
 	}
@@ -182,10 +191,52 @@ func (c *TemplateContext) ParseAndExecute(text string) (string, error) {
 }
 
 func (c *TemplateContext) Parse(text string) (*template.Template, error) {
-	return template.New(c.name).
+	tmpl, err := c.parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.snippets != nil {
+		if err := c.snippets.attach(tmpl); err != nil {
+			return nil, err
+		}
+	}
+
+	return tmpl, nil
+}
+
+// CheckSyntax parses the text without attaching any snippets, so snippets can be validated on their own.
+// Calls to snippet are still checked, because they can only be used in one way.
+func (c *TemplateContext) CheckSyntax(text string) error {
+	tmpl, err := c.parse(text)
+	if err != nil {
+		return err
+	}
+
+	if c.snippets != nil {
+		for _, t := range tmpl.Templates() {
+			if t.Tree == nil {
+				continue
+			}
+			if err := rewriteSnippetCalls(t); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *TemplateContext) parse(text string, extraFuncs ...map[string]interface{}) (*template.Template, error) {
+	tmpl := template.New(c.name).
 		Delims(DelimLeft, DelimRight).
-		Funcs(c.funcs).
-		Parse(text)
+		Funcs(c.funcs)
+
+	for _, funcs := range extraFuncs {
+		tmpl = tmpl.Funcs(funcs)
+	}
+
+	return tmpl.Parse(text)
 }
 
 func (c *TemplateContext) Execute(tmpl *template.Template) (string, error) {
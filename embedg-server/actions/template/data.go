@@ -1,13 +1,19 @@
 package template
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
 	"time"
 
 	"github.com/merlinfuchs/discordgo"
 )
 
-var standardDataMap = map[string]interface{}{}
+var standardDataMap = map[string]interface{}{
+	// Messages are rendered before anyone has voted, the actual votes are set when a vote is cast
+	"Poll": NewPollData(nil, "", false, time.Time{}),
+}
 
 type InteractionData struct {
 	state *discordgo.State
@@ -47,6 +53,66 @@ func (d *InteractionData) Command() *CommandData {
 	return NewCommandData(d.state, d.i.GuildID, &data)
 }
 
+// Values returns the values that have been picked in a user, role, mentionable or channel select menu.
+func (d *InteractionData) Values() []interface{} {
+	if d.i.Type != discordgo.InteractionMessageComponent {
+		return nil
+	}
+
+	data := d.i.MessageComponentData()
+
+	res := make([]interface{}, 0, len(data.Values))
+	for _, value := range data.Values {
+		switch data.ComponentType {
+		case discordgo.UserSelectMenuComponent, discordgo.MentionableSelectMenuComponent:
+			if role, ok := data.Resolved.Roles[value]; ok {
+				res = append(res, NewRoleData(d.state, d.i.GuildID, value, role))
+				continue
+			}
+
+			user := data.Resolved.Users[value]
+			if user == nil {
+				user = &discordgo.User{ID: value}
+			}
+
+			if member, ok := data.Resolved.Members[value]; ok {
+				member.User = user
+				res = append(res, NewMemberData(d.state, d.i.GuildID, member))
+			} else {
+				res = append(res, NewUserData(user))
+			}
+		case discordgo.RoleSelectMenuComponent:
+			res = append(res, NewRoleData(d.state, d.i.GuildID, value, data.Resolved.Roles[value]))
+		case discordgo.ChannelSelectMenuComponent:
+			res = append(res, NewChannelData(d.state, value, data.Resolved.Channels[value]))
+		}
+	}
+
+	return res
+}
+
+// NewFormData returns the submitted values of a modal by the custom id of the text inputs.
+func NewFormData(data discordgo.ModalSubmitInteractionData) map[string]string {
+	res := make(map[string]string)
+	for _, comp := range data.Components {
+		row, ok := comp.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+
+		for _, comp := range row.Components {
+			input, ok := comp.(*discordgo.TextInput)
+			if !ok {
+				continue
+			}
+
+			res[input.CustomID] = input.Value
+		}
+	}
+
+	return res
+}
+
 type UserData struct {
 	u *discordgo.User
 }
@@ -132,6 +198,59 @@ func (d *MemberData) Roles() []*RoleData {
 	return res
 }
 
+// HasRole returns whether the member has the role, the role can be passed as an id or role.
+func (d *MemberData) HasRole(role interface{}) bool {
+	roleID, err := toSnowflake(role)
+	if err != nil {
+		return false
+	}
+
+	for _, id := range d.m.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission returns whether the member has all of the given permissions.
+// Administrators and the owner of the server have all permissions.
+func (d *MemberData) HasPermission(permission interface{}) bool {
+	perms := ToInt64(permission)
+	memberPerms := d.permissions()
+	if memberPerms&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+	return memberPerms&perms == perms
+}
+
+// permissions returns the permissions of the member that have been sent with the interaction.
+// For members that aren't part of an interaction the permissions are calculated from their roles.
+func (d *MemberData) permissions() int64 {
+	if d.m.Permissions != 0 {
+		return d.m.Permissions
+	}
+
+	guild, err := d.state.Guild(d.guildID)
+	if err != nil {
+		return 0
+	}
+	if d.m.User != nil && guild.OwnerID == d.m.User.ID {
+		return discordgo.PermissionAll
+	}
+
+	var perms int64
+	if everyone, err := d.state.Role(d.guildID, d.guildID); err == nil {
+		perms |= everyone.Permissions
+	}
+	for _, roleID := range d.m.Roles {
+		if role, err := d.state.Role(d.guildID, roleID); err == nil {
+			perms |= role.Permissions
+		}
+	}
+	return perms
+}
+
 func (d *MemberData) JoinedAt() time.Time {
 	return d.m.JoinedAt
 }
@@ -482,3 +601,104 @@ func (d *AttachmentData) ID() string {
 func (d *AttachmentData) URL() string {
 	return d.a.URL
 }
+
+// ResponseData is the response of a http request action.
+type ResponseData struct {
+	status int
+	body   []byte
+}
+
+func NewResponseData(status int, body []byte) *ResponseData {
+	return &ResponseData{
+		status: status,
+		body:   body,
+	}
+}
+
+func (d *ResponseData) String() string {
+	return string(d.body)
+}
+
+func (d *ResponseData) Status() int {
+	return d.status
+}
+
+func (d *ResponseData) Body() string {
+	return string(d.body)
+}
+
+// JSON decodes the body of the response so fields can be accessed with index.
+func (d *ResponseData) JSON() (interface{}, error) {
+	var res interface{}
+	err := json.Unmarshal(d.body, &res)
+	if err != nil {
+		return nil, fmt.Errorf("response is not valid JSON: %w", err)
+	}
+	return res, nil
+}
+
+// PollData contains the votes of the poll that belongs to the message.
+type PollData struct {
+	votes    map[string]int
+	vote     string
+	closed   bool
+	closesAt time.Time
+}
+
+func NewPollData(votes map[string]int, vote string, closed bool, closesAt time.Time) *PollData {
+	return &PollData{
+		votes:    votes,
+		vote:     vote,
+		closed:   closed,
+		closesAt: closesAt,
+	}
+}
+
+func (d *PollData) Votes(option string) int {
+	return d.votes[option]
+}
+
+func (d *PollData) Total() int {
+	total := 0
+	for _, votes := range d.votes {
+		total += votes
+	}
+	return total
+}
+
+// Percent returns the share of the votes for the option, rounded to a whole number.
+func (d *PollData) Percent(option string) int {
+	total := d.Total()
+	if total == 0 {
+		return 0
+	}
+	return int(math.Round(float64(d.votes[option]) * 100 / float64(total)))
+}
+
+func (d *PollData) Results() map[string]int {
+	return maps.Clone(d.votes)
+}
+
+// Winner returns the option with the most votes, ties are resolved alphabetically.
+func (d *PollData) Winner() string {
+	winner := ""
+	for option, votes := range d.votes {
+		if winner == "" || votes > d.votes[winner] || (votes == d.votes[winner] && option < winner) {
+			winner = option
+		}
+	}
+	return winner
+}
+
+// Vote returns the option that the user has voted for.
+func (d *PollData) Vote() string {
+	return d.vote
+}
+
+func (d *PollData) Closed() bool {
+	return d.closed
+}
+
+func (d *PollData) ClosesAt() time.Time {
+	return d.closesAt
+}
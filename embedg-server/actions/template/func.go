@@ -92,6 +92,20 @@ var standardFuncMap = map[string]interface{}{
 	"newDate":         tmplNewDate,
 	"timestampToTime": tmplTimestampToTime,
 	"weekNumber":      tmplWeekNumber,
+
+	// discord formatting
+	"timestamp":      tmplTimestamp,
+	"mentionUser":    tmplMentionUser,
+	"mentionRole":    tmplMentionRole,
+	"mentionChannel": tmplMentionChannel,
+	"escapeMarkdown": tmplEscapeMarkdown,
+	"emoji":          tmplEmoji,
+
+	// colors
+	"hexToColor": tmplHexToColor,
+	"colorToHex": tmplColorToHex,
+	"rgbToColor": tmplRGBToColor,
+	"colorToRGB": tmplColorToRGB,
 }
 
 // dictionary creates a map[string]interface{} from the given parameters by
@@ -0,0 +1,167 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampStyles are the styles that Discord supports for timestamp markup.
+var timestampStyles = map[string]bool{
+	"t": true, // short time
+	"T": true, // long time
+	"d": true, // short date
+	"D": true, // long date
+	"f": true, // short date and time
+	"F": true, // long date and time
+	"R": true, // relative time
+}
+
+// tmplTimestamp formats a time or unix timestamp as Discord timestamp markup that is shown in the timezone of each user.
+func tmplTimestamp(v interface{}, style ...string) (string, error) {
+	var unix int64
+	switch t := v.(type) {
+	case time.Time:
+		unix = t.Unix()
+	case *time.Time:
+		unix = t.Unix()
+	default:
+		unix = ToInt64(v)
+	}
+
+	if len(style) == 0 || style[0] == "" {
+		return fmt.Sprintf("<t:%d>", unix), nil
+	}
+
+	if !timestampStyles[style[0]] {
+		return "", fmt.Errorf("unknown timestamp style %q", style[0])
+	}
+
+	return fmt.Sprintf("<t:%d:%s>", unix, style[0]), nil
+}
+
+// toSnowflake returns the id of a user, member, role or channel, or the value itself if it's already an id.
+func toSnowflake(v interface{}) (string, error) {
+	var id string
+	if withID, ok := v.(interface{ ID() string }); ok {
+		id = withID.ID()
+	} else {
+		id = ToString(v)
+	}
+
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return "", fmt.Errorf("%q is not a valid id", id)
+	}
+	return id, nil
+}
+
+func tmplMentionUser(v interface{}) (string, error) {
+	id, err := toSnowflake(v)
+	if err != nil {
+		return "", err
+	}
+	return "<@" + id + ">", nil
+}
+
+func tmplMentionRole(v interface{}) (string, error) {
+	id, err := toSnowflake(v)
+	if err != nil {
+		return "", err
+	}
+	return "<@&" + id + ">", nil
+}
+
+func tmplMentionChannel(v interface{}) (string, error) {
+	id, err := toSnowflake(v)
+	if err != nil {
+		return "", err
+	}
+	return "<#" + id + ">", nil
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"~", `\~`,
+	"`", "\\`",
+	"|", `\|`,
+	">", `\>`,
+	"#", `\#`,
+	"-", `\-`,
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// tmplEscapeMarkdown escapes the characters that Discord interprets as markdown, e.g. to show user input as is.
+func tmplEscapeMarkdown(s string) string {
+	return markdownReplacer.Replace(s)
+}
+
+// tmplEmoji formats a custom emoji, unicode emojis without an id are returned as is.
+func tmplEmoji(name string, args ...interface{}) string {
+	if len(args) == 0 {
+		return name
+	}
+
+	id := ToString(args[0])
+	if id == "" {
+		return name
+	}
+
+	if len(args) > 1 {
+		if animated, ok := args[1].(bool); ok && animated {
+			return fmt.Sprintf("<a:%s:%s>", name, id)
+		}
+	}
+
+	return fmt.Sprintf("<:%s:%s>", name, id)
+}
+
+// maxColor is the largest color that can be used for embeds.
+const maxColor = 0xFFFFFF
+
+// tmplHexToColor converts a hex color like #5865F2 to the integer that is used for embed colors.
+func tmplHexToColor(hex string) (int, error) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, fmt.Errorf("%q is not a valid hex color", hex)
+	}
+
+	color, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid hex color", hex)
+	}
+	return int(color), nil
+}
+
+func tmplColorToHex(v interface{}) (string, error) {
+	color := tmplToInt(v)
+	if color < 0 || color > maxColor {
+		return "", errors.New("color must be between 0 and 16777215")
+	}
+	return fmt.Sprintf("#%06X", color), nil
+}
+
+func tmplRGBToColor(r, g, b interface{}) (int, error) {
+	channels := [3]int{tmplToInt(r), tmplToInt(g), tmplToInt(b)}
+	for _, c := range channels {
+		if c < 0 || c > 255 {
+			return 0, errors.New("rgb values must be between 0 and 255")
+		}
+	}
+	return channels[0]<<16 | channels[1]<<8 | channels[2], nil
+}
+
+func tmplColorToRGB(v interface{}) ([]int, error) {
+	color := tmplToInt(v)
+	if color < 0 || color > maxColor {
+		return nil, errors.New("color must be between 0 and 16777215")
+	}
+	return []int{color >> 16 & 0xFF, color >> 8 & 0xFF, color & 0xFF}, nil
+}
@@ -0,0 +1,372 @@
+package template
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+)
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		style   []string
+		want    string
+		wantErr bool
+	}{
+		{name: "time", value: time.Unix(1700000000, 0), want: "<t:1700000000>"},
+		{name: "time pointer", value: timePtr(time.Unix(1700000000, 0)), want: "<t:1700000000>"},
+		{name: "unix int", value: 1700000000, want: "<t:1700000000>"},
+		{name: "unix string", value: "1700000000", want: "<t:1700000000>"},
+		{name: "empty style", value: 1700000000, style: []string{""}, want: "<t:1700000000>"},
+		{name: "relative style", value: 1700000000, style: []string{"R"}, want: "<t:1700000000:R>"},
+		{name: "long date style", value: 1700000000, style: []string{"D"}, want: "<t:1700000000:D>"},
+		{name: "unknown style", value: 1700000000, style: []string{"x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmplTimestamp(tt.value, tt.style...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tmplTimestamp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tmplTimestamp() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// withID is a stand-in for the user, member, role and channel data that templates pass to the mention helpers.
+type withID string
+
+func (w withID) ID() string {
+	return string(w)
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(interface{}) (string, error)
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "user id", fn: tmplMentionUser, value: "123", want: "<@123>"},
+		{name: "user int id", fn: tmplMentionUser, value: int64(123), want: "<@123>"},
+		{name: "user with id", fn: tmplMentionUser, value: withID("123"), want: "<@123>"},
+		{name: "user empty id", fn: tmplMentionUser, value: "", wantErr: true},
+		{name: "user invalid id", fn: tmplMentionUser, value: "@everyone", wantErr: true},
+		{name: "role id", fn: tmplMentionRole, value: "456", want: "<@&456>"},
+		{name: "role with id", fn: tmplMentionRole, value: withID("456"), want: "<@&456>"},
+		{name: "role empty id", fn: tmplMentionRole, value: withID(""), wantErr: true},
+		{name: "channel id", fn: tmplMentionChannel, value: "789", want: "<#789>"},
+		{name: "channel empty id", fn: tmplMentionChannel, value: "", wantErr: true},
+		{name: "channel negative id", fn: tmplMentionChannel, value: -789, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mention error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("mention = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "empty", text: "", want: ""},
+		{name: "plain", text: "hello world", want: "hello world"},
+		{name: "bold", text: "**bold**", want: `\*\*bold\*\*`},
+		{name: "underline and strike", text: "__a__ ~~b~~", want: `\_\_a\_\_ \~\~b\~\~`},
+		{name: "code", text: "`code`", want: "\\`code\\`"},
+		{name: "spoiler", text: "||secret||", want: `\|\|secret\|\|`},
+		{name: "quote and heading", text: "> # title", want: `\> \# title`},
+		{name: "list and link", text: "- [a](b)", want: `\- \[a\](b)`},
+		{name: "backslash", text: `\*`, want: `\\\*`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tmplEscapeMarkdown(tt.text); got != tt.want {
+				t.Errorf("tmplEscapeMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmoji(t *testing.T) {
+	tests := []struct {
+		name      string
+		emojiName string
+		args      []interface{}
+		want      string
+	}{
+		{name: "unicode", emojiName: "🎉", want: "🎉"},
+		{name: "empty id", emojiName: "party", args: []interface{}{""}, want: "party"},
+		{name: "custom", emojiName: "party", args: []interface{}{"123"}, want: "<:party:123>"},
+		{name: "custom int id", emojiName: "party", args: []interface{}{int64(123)}, want: "<:party:123>"},
+		{name: "animated", emojiName: "party", args: []interface{}{"123", true}, want: "<a:party:123>"},
+		{name: "not animated", emojiName: "party", args: []interface{}{"123", false}, want: "<:party:123>"},
+		{name: "animated not a bool", emojiName: "party", args: []interface{}{"123", "true"}, want: "<:party:123>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tmplEmoji(tt.emojiName, tt.args...); got != tt.want {
+				t.Errorf("tmplEmoji() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHexToColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		want    int
+		wantErr bool
+	}{
+		{name: "with hash", hex: "#5865F2", want: 0x5865F2},
+		{name: "without hash", hex: "5865f2", want: 0x5865F2},
+		{name: "short", hex: "#fff", want: 0xFFFFFF},
+		{name: "surrounding space", hex: " #000000 ", want: 0},
+		{name: "empty", hex: "", wantErr: true},
+		{name: "too short", hex: "#12345", wantErr: true},
+		{name: "too long", hex: "#1234567", wantErr: true},
+		{name: "not hex", hex: "#zzzzzz", wantErr: true},
+		{name: "sign", hex: "+12345", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmplHexToColor(tt.hex)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tmplHexToColor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tmplHexToColor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorToHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "black", color: 0, want: "#000000"},
+		{name: "blurple", color: 0x5865F2, want: "#5865F2"},
+		{name: "white", color: int64(0xFFFFFF), want: "#FFFFFF"},
+		{name: "negative", color: -1, wantErr: true},
+		{name: "too large", color: 0x1000000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmplColorToHex(tt.color)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tmplColorToHex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tmplColorToHex() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRGBToColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		rgb     [3]interface{}
+		want    int
+		wantErr bool
+	}{
+		{name: "black", rgb: [3]interface{}{0, 0, 0}, want: 0},
+		{name: "blurple", rgb: [3]interface{}{88, 101, 242}, want: 0x5865F2},
+		{name: "white", rgb: [3]interface{}{255, 255, 255}, want: 0xFFFFFF},
+		{name: "strings", rgb: [3]interface{}{"88", "101", "242"}, want: 0x5865F2},
+		{name: "red too large", rgb: [3]interface{}{256, 0, 0}, wantErr: true},
+		{name: "green negative", rgb: [3]interface{}{0, -1, 0}, wantErr: true},
+		{name: "blue too large", rgb: [3]interface{}{0, 0, 1000}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmplRGBToColor(tt.rgb[0], tt.rgb[1], tt.rgb[2])
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tmplRGBToColor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tmplRGBToColor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorToRGB(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   interface{}
+		want    []int
+		wantErr bool
+	}{
+		{name: "black", color: 0, want: []int{0, 0, 0}},
+		{name: "blurple", color: 0x5865F2, want: []int{88, 101, 242}},
+		{name: "white", color: 0xFFFFFF, want: []int{255, 255, 255}},
+		{name: "negative", color: -1, wantErr: true},
+		{name: "too large", color: 0x1000000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmplColorToRGB(tt.color)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tmplColorToRGB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tmplColorToRGB() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberHasRole(t *testing.T) {
+	member := NewMemberData(discordgo.NewState(), "1", &discordgo.Member{
+		User:  &discordgo.User{ID: "10"},
+		Roles: []string{"2", "3"},
+	})
+
+	tests := []struct {
+		name string
+		role interface{}
+		want bool
+	}{
+		{name: "role id", role: "2", want: true},
+		{name: "int role id", role: int64(3), want: true},
+		{name: "role with id", role: withID("3"), want: true},
+		{name: "missing role", role: "4", want: false},
+		{name: "empty id", role: "", want: false},
+		{name: "invalid id", role: "admin", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := member.HasRole(tt.role); got != tt.want {
+				t.Errorf("HasRole(%v) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberHasPermission(t *testing.T) {
+	state := discordgo.NewState()
+	err := state.GuildAdd(&discordgo.Guild{
+		ID:      "1",
+		OwnerID: "10",
+		Roles: []*discordgo.Role{
+			{ID: "1", Permissions: discordgo.PermissionViewChannel},
+			{ID: "2", Permissions: discordgo.PermissionManageMessages},
+			{ID: "3", Permissions: discordgo.PermissionAdministrator},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		member     *discordgo.Member
+		permission interface{}
+		want       bool
+	}{
+		{
+			name:       "interaction permissions",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}, Permissions: discordgo.PermissionBanMembers},
+			permission: discordgo.PermissionBanMembers,
+			want:       true,
+		},
+		{
+			name:       "missing interaction permission",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}, Permissions: discordgo.PermissionBanMembers},
+			permission: discordgo.PermissionKickMembers,
+			want:       false,
+		},
+		{
+			name:       "interaction administrator",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}, Permissions: discordgo.PermissionAdministrator},
+			permission: discordgo.PermissionKickMembers,
+			want:       true,
+		},
+		{
+			name:       "everyone role",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}},
+			permission: discordgo.PermissionViewChannel,
+			want:       true,
+		},
+		{
+			name:       "member role",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}, Roles: []string{"2"}},
+			permission: discordgo.PermissionManageMessages,
+			want:       true,
+		},
+		{
+			name:       "all of multiple permissions",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}, Roles: []string{"2"}},
+			permission: discordgo.PermissionManageMessages | discordgo.PermissionKickMembers,
+			want:       false,
+		},
+		{
+			name:       "administrator role",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}, Roles: []string{"3"}},
+			permission: discordgo.PermissionBanMembers,
+			want:       true,
+		},
+		{
+			name:       "unknown role",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "20"}, Roles: []string{"4"}},
+			permission: discordgo.PermissionManageMessages,
+			want:       false,
+		},
+		{
+			name:       "owner",
+			member:     &discordgo.Member{User: &discordgo.User{ID: "10"}},
+			permission: discordgo.PermissionBanMembers,
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member := NewMemberData(state, "1", tt.member)
+			if got := member.HasPermission(tt.permission); got != tt.want {
+				t.Errorf("HasPermission(%v) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberHasPermissionUnknownGuild(t *testing.T) {
+	member := NewMemberData(discordgo.NewState(), "1", &discordgo.Member{User: &discordgo.User{ID: "10"}})
+	if member.HasPermission(discordgo.PermissionViewChannel) {
+		t.Error("HasPermission() = true for a guild that isn't in the state")
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,340 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/template"
+	"github.com/botlabs-gg/yagpdb/v2/lib/template/parse"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/variables"
+)
+
+// knownDataKeys are all keys that templates can access, some of them are only available in specific situations.
+var knownDataKeys = []string{"Interaction", "Guild", "Server", "Channel", "Form", "Poll", "Choice", "Response"}
+
+// maxLintUnknownFuncs is the number of unknown functions after which linting gives up on a template.
+const maxLintUnknownFuncs = 25
+
+var parseErrorRegex = regexp.MustCompile(`(?s)^template: [^:]*:(\d+): (.*)$`)
+var unknownFuncRegex = regexp.MustCompile(`^function "(.+)" not defined$`)
+
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// LintIssue is a problem that has been found in a template.
+// The line and column are 1-based and 0 if the position is unknown.
+// Parse errors only report the line, because the parser doesn't include the offset in its errors.
+type LintIssue struct {
+	Path     string
+	Severity LintSeverity
+	Message  string
+	Line     int
+	Column   int
+}
+
+// Lint parses the text and returns all errors and warnings without executing it.
+// Unknown functions are reported as warnings, so the rest of the template can still be checked.
+func (c *TemplateContext) Lint(text string) []LintIssue {
+	if text == "" {
+		return nil
+	}
+
+	var issues []LintIssue
+
+	unknownFuncs := make(map[string]interface{})
+	var tmpl *template.Template
+	for {
+		var err error
+		tmpl, err = c.parse(text, unknownFuncs)
+		if err == nil {
+			break
+		}
+
+		line, msg := splitParseError(err)
+		if m := unknownFuncRegex.FindStringSubmatch(msg); m != nil && len(unknownFuncs) < maxLintUnknownFuncs {
+			unknownFuncs[m[1]] = func(args ...interface{}) string { return "" }
+			issues = append(issues, LintIssue{
+				Severity: LintSeverityWarning,
+				Message:  fmt.Sprintf("unknown function %q", m[1]),
+				Line:     line,
+			})
+			continue
+		}
+
+		return append(issues, LintIssue{
+			Severity: LintSeverityError,
+			Message:  msg,
+			Line:     line,
+		})
+	}
+
+	l := &linter{
+		text:     text,
+		dataKeys: make(map[string]bool, len(knownDataKeys)+len(c.data)),
+	}
+	for _, key := range knownDataKeys {
+		l.dataKeys[key] = true
+	}
+	for key := range c.data {
+		l.dataKeys[key] = true
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			l.checkLegacyVariables(t.Tree.Root)
+		}
+	}
+	l.checkDataKeys(tmpl.Tree.Root, true)
+	issues = append(issues, l.issues...)
+
+	if c.snippets != nil {
+		if err := c.snippets.attach(tmpl); err != nil {
+			issues = append(issues, LintIssue{
+				Severity: LintSeverityError,
+				Message:  err.Error(),
+			})
+		}
+	}
+
+	return issues
+}
+
+// LintMessage lints all templates of the message and its actions.
+// The path of each issue is the JSON path of the field that contains the template, e.g. embeds[1].fields[3].value.
+func (c *TemplateContext) LintMessage(m *actions.MessageWithActions) []LintIssue {
+	var issues []LintIssue
+	lint := func(path string, text string) {
+		for _, issue := range c.Lint(text) {
+			issue.Path = path
+			issues = append(issues, issue)
+		}
+	}
+
+	lint("content", m.Content)
+	lint("username", m.Username)
+	lint("avatar_url", m.AvatarURL)
+
+	for i, embed := range m.Embeds {
+		path := fmt.Sprintf("embeds[%d]", i)
+
+		lint(path+".title", embed.Title)
+		lint(path+".description", embed.Description)
+		lint(path+".url", embed.URL)
+
+		if embed.Author != nil {
+			lint(path+".author.name", embed.Author.Name)
+			lint(path+".author.url", embed.Author.URL)
+			lint(path+".author.icon_url", embed.Author.IconURL)
+		}
+
+		if embed.Footer != nil {
+			lint(path+".footer.text", embed.Footer.Text)
+			lint(path+".footer.icon_url", embed.Footer.IconURL)
+		}
+
+		if embed.Image != nil {
+			lint(path+".image.url", embed.Image.URL)
+		}
+
+		if embed.Thumbnail != nil {
+			lint(path+".thumbnail.url", embed.Thumbnail.URL)
+		}
+
+		for j, field := range embed.Fields {
+			lint(fmt.Sprintf("%s.fields[%d].name", path, j), field.Name)
+			lint(fmt.Sprintf("%s.fields[%d].value", path, j), field.Value)
+		}
+	}
+
+	for i, row := range m.Components {
+		for j, component := range row.Components {
+			path := fmt.Sprintf("components[%d].components[%d]", i, j)
+
+			lint(path+".label", component.Label)
+			lint(path+".url", component.URL)
+			lint(path+".placeholder", component.Placeholder)
+
+			for k, option := range component.Options {
+				lint(fmt.Sprintf("%s.options[%d].label", path, k), option.Label)
+				lint(fmt.Sprintf("%s.options[%d].description", path, k), option.Description)
+			}
+		}
+	}
+
+	actionSetIDs := make([]string, 0, len(m.Actions))
+	for id := range m.Actions {
+		actionSetIDs = append(actionSetIDs, id)
+	}
+	sort.Strings(actionSetIDs)
+
+	for _, id := range actionSetIDs {
+		actionSet := m.Actions[id]
+		lintActionSet("actions."+id, &actionSet, lint)
+	}
+
+	return issues
+}
+
+// lintActionSet lints the templates of all actions in the set, including the actions of nested sets.
+func lintActionSet(path string, actionSet *actions.ActionSet, lint func(path string, text string)) {
+	lint(path+".limit_response", actionSet.LimitResponse)
+
+	for i, action := range actionSet.Actions {
+		actionPath := fmt.Sprintf("%s.actions[%d]", path, i)
+
+		lint(actionPath+".text", action.Text)
+
+		if action.Request != nil {
+			for j, field := range action.Request.Fields {
+				lint(fmt.Sprintf("%s.request.fields[%d].value", actionPath, j), field.Value)
+			}
+		}
+
+		if action.Form != nil {
+			lint(actionPath+".form.title", action.Form.Title)
+			for j, field := range action.Form.Fields {
+				lint(fmt.Sprintf("%s.form.fields[%d].value", actionPath, j), field.Value)
+			}
+			lintActionSet(actionPath+".form.action_set", &action.Form.ActionSet, lint)
+		}
+
+		if action.Condition != nil {
+			lint(actionPath+".condition.expression", action.Condition.Expression)
+			lintActionSet(actionPath+".condition.then", &action.Condition.Then, lint)
+			lintActionSet(actionPath+".condition.else", &action.Condition.Else, lint)
+		}
+
+		for j := range action.Choices {
+			lintActionSet(fmt.Sprintf("%s.choices[%d].action_set", actionPath, j), &action.Choices[j].ActionSet, lint)
+		}
+	}
+}
+
+type linter struct {
+	text     string
+	dataKeys map[string]bool
+	issues   []LintIssue
+}
+
+func (l *linter) warn(pos parse.Pos, format string, args ...interface{}) {
+	line, column := textPosition(l.text, int(pos))
+	l.issues = append(l.issues, LintIssue{
+		Severity: LintSeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+		Line:     line,
+		Column:   column,
+	})
+}
+
+// checkLegacyVariables warns about variables like {user.name} that are still filled in, but have been replaced by templates.
+func (l *linter) checkLegacyVariables(root *parse.ListNode) {
+	walkLists(root, func(list *parse.ListNode) {
+		for _, node := range list.Nodes {
+			text, ok := node.(*parse.TextNode)
+			if !ok {
+				continue
+			}
+
+			for _, loc := range variables.FindVariables(string(text.Text)) {
+				l.warn(text.Pos+parse.Pos(loc[0]), "%s uses the legacy variable syntax, use a template instead", text.Text[loc[0]:loc[1]])
+			}
+		}
+	})
+}
+
+// checkDataKeys warns about keys of the data that don't exist, because they silently render as <no value>.
+// Fields are only checked while dot is the data itself, range and with change dot to something else.
+func (l *linter) checkDataKeys(node parse.Node, rootDot bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			l.checkDataKeys(child, rootDot)
+		}
+	case *parse.ActionNode:
+		l.checkDataKeys(n.Pipe, rootDot)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			l.checkDataKeys(cmd, rootDot)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			l.checkDataKeys(arg, rootDot)
+		}
+	case *parse.ChainNode:
+		l.checkDataKeys(n.Node, rootDot)
+	case *parse.FieldNode:
+		if rootDot {
+			l.checkDataKey(n.Pos, n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			l.checkDataKey(n.Pos, n.Ident[1])
+		}
+	case *parse.IfNode:
+		l.checkDataKeys(n.Pipe, rootDot)
+		l.checkDataKeys(n.List, rootDot)
+		l.checkDataKeys(n.ElseList, rootDot)
+	case *parse.WhileNode:
+		l.checkDataKeys(n.Pipe, rootDot)
+		l.checkDataKeys(n.List, rootDot)
+		l.checkDataKeys(n.ElseList, rootDot)
+	case *parse.RangeNode:
+		l.checkDataKeys(n.Pipe, rootDot)
+		l.checkDataKeys(n.List, false)
+		l.checkDataKeys(n.ElseList, rootDot)
+	case *parse.WithNode:
+		l.checkDataKeys(n.Pipe, rootDot)
+		l.checkDataKeys(n.List, false)
+		l.checkDataKeys(n.ElseList, rootDot)
+	case *parse.TryNode:
+		l.checkDataKeys(n.List, rootDot)
+		l.checkDataKeys(n.CatchList, rootDot)
+	case *parse.TemplateNode:
+		l.checkDataKeys(n.Pipe, rootDot)
+	}
+}
+
+func (l *linter) checkDataKey(pos parse.Pos, key string) {
+	if !l.dataKeys[key] {
+		l.warn(pos, "unknown data key %q", key)
+	}
+}
+
+// splitParseError returns the line and the message of an error returned by the parser.
+// The parser only reports the line of the token that caused the error, so there is no column.
+func splitParseError(err error) (int, string) {
+	m := parseErrorRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, err.Error()
+	}
+
+	line, _ := strconv.Atoi(m[1])
+	return line, m[2]
+}
+
+// textPosition converts a byte offset in the text to a line and column.
+func textPosition(text string, pos int) (int, int) {
+	if pos > len(text) {
+		pos = len(text)
+	}
+
+	before := text[:pos]
+	line := 1 + strings.Count(before, "\n")
+	column := 1 + utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:])
+	return line, column
+}
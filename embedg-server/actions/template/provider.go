@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/merlinfuchs/discordgo"
@@ -41,6 +42,10 @@ func (p *InteractionProvider) ProvideData(data map[string]interface{}) {
 	data["Server"] = guildData
 
 	data["Channel"] = NewChannelData(p.state, p.interaction.ChannelID, nil)
+
+	if p.interaction.Type == discordgo.InteractionModalSubmit {
+		data["Form"] = NewFormData(p.interaction.ModalSubmitData())
+	}
 }
 
 type GuildProvider struct {
@@ -85,6 +90,108 @@ func (p *ChannelProvider) ProvideData(data map[string]interface{}) {
 	data["Channel"] = NewChannelData(p.state, p.channelID, p.channel)
 }
 
+// StateProvider gives templates read access to the members, roles and channels of the guild.
+// All lookups are answered from the state cache, so templates can't cause any requests to Discord.
+type StateProvider struct {
+	state   *discordgo.State
+	guildID string
+}
+
+func NewStateProvider(state *discordgo.State, guildID string) *StateProvider {
+	return &StateProvider{
+		state:   state,
+		guildID: guildID,
+	}
+}
+
+func (p *StateProvider) ProvideFuncs(funcs map[string]interface{}) {
+	funcs["getMember"] = p.getMember
+	funcs["getRole"] = p.getRole
+	funcs["getChannel"] = p.getChannel
+	funcs["guildRoles"] = p.guildRoles
+}
+
+func (p *StateProvider) ProvideData(data map[string]interface{}) {}
+
+func (p *StateProvider) getMember(user interface{}) (*MemberData, error) {
+	userID, err := toSnowflake(user)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := p.state.Member(p.guildID, userID)
+	if err != nil {
+		if err == discordgo.ErrStateNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return NewMemberData(p.state, p.guildID, member), nil
+}
+
+func (p *StateProvider) getRole(role interface{}) (*RoleData, error) {
+	roleID, err := toSnowflake(role)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := p.state.Role(p.guildID, roleID)
+	if err != nil {
+		if err == discordgo.ErrStateNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return NewRoleData(p.state, p.guildID, roleID, r), nil
+}
+
+func (p *StateProvider) getChannel(channel interface{}) (*ChannelData, error) {
+	channelID, err := toSnowflake(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := p.state.Channel(channelID)
+	if err != nil {
+		if err == discordgo.ErrStateNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Channels of other guilds are treated as if they don't exist
+	if c.GuildID != p.guildID {
+		return nil, nil
+	}
+
+	return NewChannelData(p.state, channelID, c), nil
+}
+
+// guildRoles returns all roles of the guild ordered from the highest to the lowest role.
+func (p *StateProvider) guildRoles() ([]*RoleData, error) {
+	guild, err := p.state.Guild(p.guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.state.RLock()
+	roles := make([]*discordgo.Role, len(guild.Roles))
+	copy(roles, guild.Roles)
+	p.state.RUnlock()
+
+	sort.SliceStable(roles, func(i, j int) bool {
+		return roles[i].Position > roles[j].Position
+	})
+
+	res := make([]*RoleData, len(roles))
+	for i, role := range roles {
+		res[i] = NewRoleData(p.state, p.guildID, role.ID, role)
+	}
+	return res, nil
+}
+
 type KVProvider struct {
 	guildID      string
 	kvStore      store.KVEntryStore
@@ -0,0 +1,359 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/botlabs-gg/yagpdb/v2/lib/template"
+	"github.com/botlabs-gg/yagpdb/v2/lib/template/parse"
+	"github.com/merlinfuchs/embed-generator/embedg-server/store"
+)
+
+const DefaultMaxSnippetDepth = 3
+const DefaultMaxSnippetsSize = 16 * 1024
+
+// SnippetProvider makes the template snippets of a guild available to all templates of the context.
+// Snippets can be used with {{template "name" .}} or {{snippet "name"}} which passes the root data to the snippet.
+type SnippetProvider struct {
+	guildID      string
+	snippetStore store.TemplateSnippetStore
+	maxDepth     int
+	maxSize      int
+
+	snippets map[string]string
+}
+
+// MaxSnippetDepth returns the maximum depth of nested snippets for the plan limit, plans without a limit use the default.
+func MaxSnippetDepth(maxDepth int) int {
+	if maxDepth == 0 {
+		return DefaultMaxSnippetDepth
+	}
+	return maxDepth
+}
+
+// MaxSnippetsSize returns the maximum total size of the snippets of a guild for the plan limit, plans without a limit use the default.
+func MaxSnippetsSize(maxSize int) int {
+	if maxSize == 0 {
+		return DefaultMaxSnippetsSize
+	}
+	return maxSize
+}
+
+func NewSnippetProvider(guildID string, snippetStore store.TemplateSnippetStore, maxDepth int, maxSize int) *SnippetProvider {
+	return &SnippetProvider{
+		guildID:      guildID,
+		snippetStore: snippetStore,
+		maxDepth:     MaxSnippetDepth(maxDepth),
+		maxSize:      MaxSnippetsSize(maxSize),
+	}
+}
+
+func (p *SnippetProvider) ProvideFuncs(funcs map[string]interface{}) {
+	// Calls to snippet are replaced with template invocations when parsing, so this is only called when it's misused
+	funcs["snippet"] = func(name string, data ...interface{}) (string, error) {
+		return "", errors.New("snippet can only be used on its own like {{snippet \"name\"}}")
+	}
+}
+
+func (p *SnippetProvider) ProvideData(data map[string]interface{}) {}
+
+// loadSnippets fetches the snippets of the guild once and keeps them around for all further templates.
+func (p *SnippetProvider) loadSnippets() (map[string]string, error) {
+	if p.snippets != nil {
+		return p.snippets, nil
+	}
+
+	snippets, err := p.snippetStore.GetTemplateSnippets(context.TODO(), p.guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template snippets: %w", err)
+	}
+
+	size := 0
+	res := make(map[string]string, len(snippets))
+	for _, snippet := range snippets {
+		size += len(snippet.Content)
+		res[snippet.Name] = snippet.Content
+	}
+
+	if size > p.maxSize {
+		return nil, fmt.Errorf("template snippets exceed the maximum total size of %d bytes", p.maxSize)
+	}
+
+	p.snippets = res
+	return res, nil
+}
+
+// attach adds all snippets that are referenced by the template, directly or through other snippets, to the template.
+// Snippets never replace templates with the same name that are defined in the template itself.
+func (p *SnippetProvider) attach(tmpl *template.Template) error {
+	visited := make(map[string]bool)
+
+	for {
+		changed := false
+		for _, t := range tmpl.Templates() {
+			if visited[t.Name()] || t.Tree == nil {
+				continue
+			}
+			visited[t.Name()] = true
+			changed = true
+
+			if err := rewriteSnippetCalls(t); err != nil {
+				return err
+			}
+			for _, name := range templateReferences(t.Tree.Root) {
+				if tmpl.Lookup(name) != nil {
+					continue
+				}
+
+				snippets, err := p.loadSnippets()
+				if err != nil {
+					return err
+				}
+
+				content, ok := snippets[name]
+				if !ok {
+					return fmt.Errorf("unknown template snippet %q", name)
+				}
+
+				_, err = tmpl.New(name).Parse(content)
+				if err != nil {
+					return fmt.Errorf("failed to parse template snippet %q: %w", name, err)
+				}
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	depth, err := templateDepth(tmpl, tmpl.Name(), make(map[string]int))
+	if err != nil {
+		return err
+	}
+	if depth > p.maxDepth {
+		return fmt.Errorf("snippets can't be nested more than %d levels deep", p.maxDepth)
+	}
+
+	return nil
+}
+
+// templateDepth returns how many levels deep the template invokes other templates.
+// Recursive templates are rejected, because their depth can't be known without executing them.
+func templateDepth(tmpl *template.Template, name string, depths map[string]int) (int, error) {
+	if depth, ok := depths[name]; ok {
+		if depth < 0 {
+			return 0, fmt.Errorf("template %q invokes itself recursively", name)
+		}
+		return depth, nil
+	}
+
+	t := tmpl.Lookup(name)
+	if t == nil || t.Tree == nil {
+		return 0, nil
+	}
+
+	// Mark the template as in progress to detect cycles
+	depths[name] = -1
+
+	depth := 0
+	for _, ref := range templateReferences(t.Tree.Root) {
+		refDepth, err := templateDepth(tmpl, ref, depths)
+		if err != nil {
+			return 0, err
+		}
+		if refDepth+1 > depth {
+			depth = refDepth + 1
+		}
+	}
+
+	depths[name] = depth
+	return depth, nil
+}
+
+// rewriteSnippetCalls replaces all {{snippet "name"}} actions in the template with template invocations.
+// Any other use of snippet is rejected here, otherwise it would only fail once the template is executed.
+func rewriteSnippetCalls(t *template.Template) error {
+	walkLists(t.Tree.Root, func(list *parse.ListNode) {
+		for i, node := range list.Nodes {
+			if action, ok := node.(*parse.ActionNode); ok {
+				if tn := snippetCallToTemplate(action); tn != nil {
+					list.Nodes[i] = tn
+				}
+			}
+		}
+	})
+
+	if pipe := findSnippetPipe(t.Tree.Root); pipe != nil {
+		location, context := t.Tree.ErrorContext(pipe)
+		return fmt.Errorf("%s: snippet can only be used on its own with a constant name like {{snippet \"name\"}}, not in {{%s}}", location, context)
+	}
+	return nil
+}
+
+// findSnippetPipe returns the first pipeline in the tree that uses snippet.
+func findSnippetPipe(node parse.Node) *parse.PipeNode {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if pipe := findSnippetPipe(child); pipe != nil {
+				return pipe
+			}
+		}
+	case *parse.ActionNode:
+		return pipeIfUsesSnippet(n.Pipe)
+	case *parse.TemplateNode:
+		return pipeIfUsesSnippet(n.Pipe)
+	case *parse.ReturnNode:
+		return pipeIfUsesSnippet(n.Pipe)
+	case *parse.IfNode:
+		return findSnippetPipeInBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return findSnippetPipeInBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return findSnippetPipeInBranch(&n.BranchNode)
+	case *parse.WhileNode:
+		return findSnippetPipeInBranch(&n.BranchNode)
+	case *parse.TryNode:
+		if pipe := findSnippetPipe(n.List); pipe != nil {
+			return pipe
+		}
+		return findSnippetPipe(n.CatchList)
+	}
+	return nil
+}
+
+func findSnippetPipeInBranch(b *parse.BranchNode) *parse.PipeNode {
+	if pipe := pipeIfUsesSnippet(b.Pipe); pipe != nil {
+		return pipe
+	}
+	if pipe := findSnippetPipe(b.List); pipe != nil {
+		return pipe
+	}
+	return findSnippetPipe(b.ElseList)
+}
+
+func pipeIfUsesSnippet(pipe *parse.PipeNode) *parse.PipeNode {
+	if pipe != nil && usesSnippet(pipe) {
+		return pipe
+	}
+	return nil
+}
+
+// usesSnippet returns whether the snippet function is called anywhere in the node, including nested pipelines.
+func usesSnippet(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.IdentifierNode:
+		return n.Ident == "snippet"
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if usesSnippet(cmd) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if usesSnippet(arg) {
+				return true
+			}
+		}
+	case *parse.ChainNode:
+		return usesSnippet(n.Node)
+	}
+	return false
+}
+
+// templateReferences returns the names of all templates that are invoked in the tree.
+func templateReferences(root *parse.ListNode) []string {
+	var names []string
+	walkLists(root, func(list *parse.ListNode) {
+		for _, node := range list.Nodes {
+			if tn, ok := node.(*parse.TemplateNode); ok {
+				names = append(names, tn.Name)
+			}
+		}
+	})
+	return names
+}
+
+// walkLists calls fn for every list of nodes in the tree, including the lists of nested control structures.
+func walkLists(node parse.Node, fn func(list *parse.ListNode)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		fn(n)
+		for _, child := range n.Nodes {
+			walkLists(child, fn)
+		}
+	case *parse.IfNode:
+		walkLists(n.List, fn)
+		walkLists(n.ElseList, fn)
+	case *parse.RangeNode:
+		walkLists(n.List, fn)
+		walkLists(n.ElseList, fn)
+	case *parse.WithNode:
+		walkLists(n.List, fn)
+		walkLists(n.ElseList, fn)
+	case *parse.WhileNode:
+		walkLists(n.List, fn)
+		walkLists(n.ElseList, fn)
+	case *parse.TryNode:
+		walkLists(n.List, fn)
+		walkLists(n.CatchList, fn)
+	}
+}
+
+// snippetCallToTemplate turns {{snippet "name"}} into {{template "name" $}} and {{snippet "name" data}} into {{template "name" data}}.
+// It returns nil if the action isn't a plain snippet call.
+func snippetCallToTemplate(action *parse.ActionNode) *parse.TemplateNode {
+	pipe := action.Pipe
+	if pipe == nil || len(pipe.Decl) != 0 || len(pipe.Cmds) != 1 {
+		return nil
+	}
+
+	args := pipe.Cmds[0].Args
+	if len(args) < 2 || len(args) > 3 {
+		return nil
+	}
+
+	ident, ok := args[0].(*parse.IdentifierNode)
+	if !ok || ident.Ident != "snippet" {
+		return nil
+	}
+
+	name, ok := args[1].(*parse.StringNode)
+	if !ok {
+		return nil
+	}
+
+	var data parse.Node
+	if len(args) == 3 {
+		data = args[2]
+	} else {
+		data = &parse.VariableNode{NodeType: parse.NodeVariable, Pos: action.Pos, Ident: []string{"$"}}
+	}
+
+	return &parse.TemplateNode{
+		NodeType: parse.NodeTemplate,
+		Pos:      action.Pos,
+		Line:     action.Line,
+		Name:     name.Text,
+		Pipe: &parse.PipeNode{
+			NodeType: parse.NodePipe,
+			Pos:      pipe.Pos,
+			Line:     pipe.Line,
+			Cmds: []*parse.CommandNode{
+				{NodeType: parse.NodeCommand, Pos: pipe.Cmds[0].Pos, Args: []parse.Node{data}},
+			},
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package template
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/model"
+)
+
+// snippetStore serves the snippets from memory, the keys are the names of the snippets.
+type snippetStore map[string]string
+
+func (s snippetStore) GetTemplateSnippets(ctx context.Context, guildID string) ([]model.TemplateSnippet, error) {
+	res := make([]model.TemplateSnippet, 0, len(s))
+	for name, content := range s {
+		res = append(res, model.TemplateSnippet{GuildID: guildID, Name: name, Content: content})
+	}
+	return res, nil
+}
+
+func TestSnippets(t *testing.T) {
+	snippets := snippetStore{
+		"greet":   `Hello {{.Name}}`,
+		"level1":  `1{{snippet "level2"}}`,
+		"level2":  `2{{snippet "level3"}}`,
+		"level3":  `3{{snippet "level4"}}`,
+		"level4":  `4`,
+		"self":    `{{snippet "self"}}`,
+		"ping":    `{{snippet "pong"}}`,
+		"pong":    `{{snippet "ping"}}`,
+		"broken":  `{{if}}`,
+		"misused": `{{snippet "greet" | print}}`,
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr string
+	}{
+		{name: "snippet", text: `{{snippet "greet"}}!`, want: "Hello Bob!"},
+		{name: "template", text: `{{template "greet" .}}!`, want: "Hello Bob!"},
+		{name: "snippet with data", text: `{{snippet "greet" (sdict "Name" "Alice")}}`, want: "Hello Alice"},
+		{name: "snippet in control structure", text: `{{if true}}{{snippet "greet"}}{{end}}`, want: "Hello Bob"},
+		{name: "nested up to the limit", text: `{{snippet "level2"}}`, want: "234"},
+		{name: "nested too deep", text: `{{snippet "level1"}}`, wantErr: "can't be nested more than 3 levels deep"},
+		{name: "recursive", text: `{{snippet "self"}}`, wantErr: `"self" invokes itself recursively`},
+		{name: "cycle", text: `{{snippet "ping"}}`, wantErr: "invokes itself recursively"},
+		{name: "local define shadows snippet", text: `{{define "greet"}}Bye{{end}}{{snippet "greet"}}`, want: "Bye"},
+		{name: "local define shadows nested snippet", text: `{{define "level3"}}x{{end}}{{snippet "level1"}}`, want: "12x"},
+		{name: "unknown snippet", text: `{{snippet "missing"}}`, wantErr: `unknown template snippet "missing"`},
+		{name: "invalid snippet", text: `{{snippet "broken"}}`, wantErr: `failed to parse template snippet "broken"`},
+		{name: "variable name", text: `{{$name := "greet"}}{{snippet $name}}`, wantErr: "snippet can only be used on its own"},
+		{name: "pipeline", text: `{{snippet "greet" | print}}`, wantErr: "snippet can only be used on its own"},
+		{name: "declaration", text: `{{$s := snippet "greet"}}`, wantErr: "snippet can only be used on its own"},
+		{name: "argument", text: `{{print (snippet "greet")}}`, wantErr: "snippet can only be used on its own"},
+		{name: "condition", text: `{{if snippet "greet"}}{{end}}`, wantErr: "snippet can only be used on its own"},
+		{name: "data", text: `{{snippet "greet" (snippet "greet")}}`, wantErr: "snippet can only be used on its own"},
+		{name: "misused in snippet", text: `{{snippet "misused"}}`, wantErr: "snippet can only be used on its own"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewContext("test", 0, NewSnippetProvider("1", snippets, 3, 0))
+			c.data["Name"] = "Bob"
+
+			got, err := c.ParseAndExecute(tt.text)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseAndExecute() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAndExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAndExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippetsMaxSize(t *testing.T) {
+	snippets := snippetStore{
+		"a": strings.Repeat("a", 60),
+		"b": strings.Repeat("b", 50),
+	}
+
+	tests := []struct {
+		name    string
+		maxSize int
+		text    string
+		wantErr bool
+	}{
+		{name: "within size", maxSize: 110, text: `{{snippet "a"}}`},
+		{name: "exceeds size", maxSize: 100, text: `{{snippet "a"}}`, wantErr: true},
+		{name: "all snippets count", maxSize: 100, text: `{{snippet "b"}}`, wantErr: true},
+		{name: "no snippets used", maxSize: 100, text: `{{print "a"}}`},
+		{name: "default size", maxSize: 0, text: `{{snippet "a"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewContext("test", 0, NewSnippetProvider("1", snippets, 0, tt.maxSize))
+
+			_, err := c.Parse(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "exceed the maximum total size") {
+				t.Errorf("Parse() error = %v, want size error", err)
+			}
+		})
+	}
+}
+
+func TestCheckSyntaxSnippetCalls(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "snippet", text: `{{snippet "greet"}}`},
+		{name: "unknown snippet", text: `{{snippet "missing"}}`},
+		{name: "snippet in define", text: `{{define "a"}}{{snippet "greet"}}{{end}}`},
+		{name: "variable name", text: `{{snippet .Name}}`, wantErr: true},
+		{name: "pipeline", text: `{{snippet "greet" | print}}`, wantErr: true},
+		{name: "misused in define", text: `{{define "a"}}{{print (snippet "greet")}}{{end}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewContext("test", 0, NewSnippetProvider("1", snippetStore{}, 0, 0))
+
+			err := c.CheckSyntax(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckSyntax() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
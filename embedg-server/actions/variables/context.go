@@ -37,6 +37,11 @@ func (v *VariableContext) FillString(s string) string {
 	return res
 }
 
+// FindVariables returns the start and end index of all variables in the string.
+func FindVariables(s string) [][]int {
+	return variableRegex.FindAllStringIndex(s, -1)
+}
+
 func (v *VariableContext) FillMessage(m *actions.MessageWithActions) {
 	m.Content = v.FillString(m.Content)
 	m.Username = v.FillString(m.Username)
@@ -0,0 +1,77 @@
+package action_logs
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/guregu/null.v4"
+)
+
+type ActionLogsHandler struct {
+	pg *postgres.PostgresStore
+	am *access.AccessManager
+}
+
+func New(pg *postgres.PostgresStore, am *access.AccessManager) *ActionLogsHandler {
+	return &ActionLogsHandler{
+		pg: pg,
+		am: am,
+	}
+}
+
+func (h *ActionLogsHandler) HandleListActionLogs(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	actionLogs, err := h.pg.Q.GetActionLogs(c.Context(), pgmodel.GetActionLogsParams{
+		GuildID: guildID,
+		Limit:   int32(limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get action logs")
+		return err
+	}
+
+	res := make([]wire.ActionLogWire, len(actionLogs))
+	for i, actionLog := range actionLogs {
+		res[i] = actionLogModelToWire(actionLog)
+	}
+
+	return c.JSON(wire.ActionLogListResponseWire{
+		Success: true,
+		Data:    res,
+	})
+}
+
+func actionLogModelToWire(model pgmodel.ActionLog) wire.ActionLogWire {
+	return wire.ActionLogWire{
+		ID:        model.ID,
+		GuildID:   model.GuildID,
+		ChannelID: model.ChannelID,
+		MessageID: null.String{NullString: model.MessageID},
+		SourceID:  model.SourceID,
+		UserID:    model.UserID,
+		Actions:   model.Actions,
+		Success:   model.Success,
+		Error:     null.String{NullString: model.Error},
+		CreatedAt: model.CreatedAt,
+		ExpiresAt: model.ExpiresAt,
+	}
+}
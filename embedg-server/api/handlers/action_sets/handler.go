@@ -0,0 +1,246 @@
+package action_sets
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/parser"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/session"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/store"
+	"github.com/merlinfuchs/embed-generator/embedg-server/util"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/guregu/null.v4"
+)
+
+type ActionSetsHandler struct {
+	pg           *postgres.PostgresStore
+	am           *access.AccessManager
+	planStore    store.PlanStore
+	actionParser *parser.ActionParser
+}
+
+func New(pg *postgres.PostgresStore, am *access.AccessManager, planStore store.PlanStore, actionParser *parser.ActionParser) *ActionSetsHandler {
+	return &ActionSetsHandler{
+		pg:           pg,
+		am:           am,
+		planStore:    planStore,
+		actionParser: actionParser,
+	}
+}
+
+func (h *ActionSetsHandler) HandleListActionSets(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	actionSets, err := h.pg.Q.GetGuildActionSets(c.Context(), guildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get guild action sets")
+		return err
+	}
+
+	res := make([]wire.ActionSetWire, len(actionSets))
+	for i, actionSet := range actionSets {
+		res[i] = actionSetModelToWire(actionSet)
+	}
+
+	return c.JSON(wire.ActionSetListResponseWire{
+		Success: true,
+		Data:    res,
+	})
+}
+
+func (h *ActionSetsHandler) HandleGetActionSet(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	actionSet, err := h.pg.Q.GetGuildActionSet(c.Context(), pgmodel.GetGuildActionSetParams{
+		ID:      c.Params("actionSetID"),
+		GuildID: guildID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_action_set", "The action set does not exist.")
+		}
+		return err
+	}
+
+	return c.JSON(wire.ActionSetGetResponseWire{
+		Success: true,
+		Data:    actionSetModelToWire(actionSet),
+	})
+}
+
+func (h *ActionSetsHandler) HandleCreateActionSet(c *fiber.Ctx, req wire.ActionSetCreateRequestWire) error {
+	session := c.Locals("session").(*session.Session)
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	features, err := h.planStore.GetPlanFeaturesForGuild(c.Context(), guildID)
+	if err != nil {
+		return err
+	}
+
+	existingCount, err := h.pg.Q.CountGuildActionSets(c.Context(), guildID)
+	if err != nil {
+		return err
+	}
+
+	if int(existingCount) >= features.MaxActionSets {
+		return helpers.Forbidden("insufficient_plan", "You have reached the maximum number of action sets for your plan!")
+	}
+
+	if err := h.validateActionSet(req.Actions, session.UserID, guildID); err != nil {
+		return err
+	}
+
+	rawDerivedPerms, err := h.derivePermissions(session.UserID, guildID)
+	if err != nil {
+		return err
+	}
+
+	actionSet, err := h.pg.Q.InsertGuildActionSet(c.Context(), pgmodel.InsertGuildActionSetParams{
+		ID:                 util.UniqueID(),
+		GuildID:            guildID,
+		Name:               req.Name,
+		Description:        req.Description.NullString,
+		Actions:            req.Actions,
+		DerivedPermissions: rawDerivedPerms,
+		CreatedAt:          time.Now().UTC(),
+		UpdatedAt:          time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to insert guild action set")
+		return err
+	}
+
+	return c.JSON(wire.ActionSetCreateResponseWire{
+		Success: true,
+		Data:    actionSetModelToWire(actionSet),
+	})
+}
+
+// HandleUpdateActionSet changes the action set for all messages that reference it.
+// The actions are executed with the permissions of the user that has last updated the action set.
+func (h *ActionSetsHandler) HandleUpdateActionSet(c *fiber.Ctx, req wire.ActionSetUpdateRequestWire) error {
+	session := c.Locals("session").(*session.Session)
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	if err := h.validateActionSet(req.Actions, session.UserID, guildID); err != nil {
+		return err
+	}
+
+	rawDerivedPerms, err := h.derivePermissions(session.UserID, guildID)
+	if err != nil {
+		return err
+	}
+
+	actionSet, err := h.pg.Q.UpdateGuildActionSet(c.Context(), pgmodel.UpdateGuildActionSetParams{
+		ID:                 c.Params("actionSetID"),
+		GuildID:            guildID,
+		Name:               req.Name,
+		Description:        req.Description.NullString,
+		Actions:            req.Actions,
+		DerivedPermissions: rawDerivedPerms,
+		UpdatedAt:          time.Now().UTC(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_action_set", "The action set does not exist.")
+		}
+		log.Error().Err(err).Msg("Failed to update guild action set")
+		return err
+	}
+
+	return c.JSON(wire.ActionSetUpdateResponseWire{
+		Success: true,
+		Data:    actionSetModelToWire(actionSet),
+	})
+}
+
+func (h *ActionSetsHandler) HandleDeleteActionSet(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	_, err := h.pg.Q.DeleteGuildActionSet(c.Context(), pgmodel.DeleteGuildActionSetParams{
+		ID:      c.Params("actionSetID"),
+		GuildID: guildID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_action_set", "The action set does not exist.")
+		}
+		log.Error().Err(err).Msg("Failed to delete guild action set")
+		return err
+	}
+
+	return c.JSON(wire.ActionSetDeleteResponseWire{
+		Success: true,
+		Data:    struct{}{},
+	})
+}
+
+func (h *ActionSetsHandler) derivePermissions(userID string, guildID string) (json.RawMessage, error) {
+	derivedPerms, err := h.actionParser.DerivePermissionsForActions(userID, guildID, "")
+	if err != nil {
+		return nil, helpers.BadRequest("invalid_actions", err.Error())
+	}
+
+	return json.Marshal(derivedPerms)
+}
+
+// validateActionSet makes sure that the actions are valid and that the user is allowed to use them.
+// The actions aren't tied to a channel, so only the permissions of the user in the guild are checked.
+func (h *ActionSetsHandler) validateActionSet(raw json.RawMessage, userID string, guildID string) error {
+	actionSet := actions.ActionSet{}
+	err := json.Unmarshal(raw, &actionSet)
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", "The actions are not valid.")
+	}
+
+	if actionSet.LibraryID != "" {
+		return helpers.BadRequest("invalid_actions", "Action sets in the library can't reference other action sets.")
+	}
+
+	err = h.actionParser.CheckPermissionsForActionSets(map[string]actions.ActionSet{"": actionSet}, userID, guildID, "")
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", err.Error())
+	}
+
+	return nil
+}
+
+func actionSetModelToWire(model pgmodel.GuildActionSet) wire.ActionSetWire {
+	return wire.ActionSetWire{
+		ID:          model.ID,
+		GuildID:     model.GuildID,
+		Name:        model.Name,
+		Description: null.String{NullString: model.Description},
+		Actions:     model.Actions,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
@@ -0,0 +1,114 @@
+package action_simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/handler"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/parser"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/session"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/bot"
+	"gopkg.in/guregu/null.v4"
+)
+
+type ActionSimulatorHandler struct {
+	bot           *bot.Bot
+	am            *access.AccessManager
+	actionParser  *parser.ActionParser
+	actionHandler *handler.ActionHandler
+}
+
+func New(bot *bot.Bot, am *access.AccessManager, actionParser *parser.ActionParser, actionHandler *handler.ActionHandler) *ActionSimulatorHandler {
+	return &ActionSimulatorHandler{
+		bot:           bot,
+		am:            am,
+		actionParser:  actionParser,
+		actionHandler: actionHandler,
+	}
+}
+
+// HandleSimulateActions returns what the action set would do if the member clicked a button on a message that has been sent by the current user.
+// Nothing is sent to Discord and no data is changed.
+func (h *ActionSimulatorHandler) HandleSimulateActions(c *fiber.Ctx, req wire.ActionSimulateRequestWire) error {
+	session := c.Locals("session").(*session.Session)
+
+	if err := h.am.CheckChannelAccessForRequest(c, req.ChannelID); err != nil {
+		return err
+	}
+
+	channel, err := h.bot.State.Channel(req.ChannelID)
+	if err != nil {
+		return fmt.Errorf("Failed to get channel: %w", err)
+	}
+
+	actionSet := actions.ActionSet{}
+	err = json.Unmarshal(req.ActionSet, &actionSet)
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", "The action set is not valid.")
+	}
+
+	derivedPerms, err := h.actionParser.DerivePermissionsForActions(session.UserID, channel.GuildID, req.ChannelID)
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", err.Error())
+	}
+
+	var permissions int64
+	if req.Member.Permissions != "" {
+		permissions, err = strconv.ParseInt(req.Member.Permissions, 10, 64)
+		if err != nil {
+			return helpers.BadRequest("invalid_permissions", "The permissions of the member are not valid.")
+		}
+	}
+
+	userID := session.UserID
+	if req.Member.UserID.Valid {
+		userID = req.Member.UserID.String
+	}
+
+	events, err := h.actionHandler.Simulate(c.Context(), h.bot.State, handler.SimulationParams{
+		GuildID:   channel.GuildID,
+		ChannelID: req.ChannelID,
+		Member: &discordgo.Member{
+			GuildID:     channel.GuildID,
+			User:        &discordgo.User{ID: userID},
+			Roles:       req.Member.Roles,
+			Permissions: permissions,
+		},
+		Locale:       discordgo.Locale(req.Member.Locale),
+		DerivedPerms: derivedPerms,
+		ActionSet:    actionSet,
+	})
+	if err != nil {
+		return err
+	}
+
+	res := make([]wire.ActionSimulationEventWire, len(events))
+	for i, event := range events {
+		res[i] = wire.ActionSimulationEventWire{
+			Type:      string(event.Type),
+			ChannelID: null.NewString(event.ChannelID, event.ChannelID != ""),
+			MessageID: null.NewString(event.MessageID, event.MessageID != ""),
+			UserID:    null.NewString(event.UserID, event.UserID != ""),
+			RoleID:    null.NewString(event.RoleID, event.RoleID != ""),
+			Key:       null.NewString(event.Key, event.Key != ""),
+			Value:     null.NewString(event.Value, event.Value != ""),
+			Method:    null.NewString(event.Method, event.Method != ""),
+			URL:       null.NewString(event.URL, event.URL != ""),
+			Data:      event.Data,
+		}
+	}
+
+	return c.JSON(wire.ActionSimulateResponseWire{
+		Success: true,
+		Data: wire.ActionSimulateResponseDataWire{
+			Events: res,
+		},
+	})
+}
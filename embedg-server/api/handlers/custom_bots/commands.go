@@ -129,6 +129,11 @@ func (h *CustomBotsHandler) HandleCreateCustomCommand(c *fiber.Ctx, req wire.Cus
 		return err
 	}
 
+	err = h.actionParser.CheckLibraryActionSets(map[string]actions.ActionSet{"": actionSet}, session.UserID, guildID, "")
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", err.Error())
+	}
+
 	derivedPerms, err := h.actionParser.DerivePermissionsForActions(session.UserID, guildID, "")
 	if err != nil {
 		return helpers.BadRequest("invalid_actions", err.Error())
@@ -202,6 +207,11 @@ func (h *CustomBotsHandler) HandleUpdateCustomCommand(c *fiber.Ctx, req wire.Cus
 		return err
 	}
 
+	err = h.actionParser.CheckLibraryActionSets(map[string]actions.ActionSet{"": actionSet}, session.UserID, guildID, "")
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", err.Error())
+	}
+
 	derivedPerms, err := h.actionParser.DerivePermissionsForActions(session.UserID, guildID, "")
 	if err != nil {
 		return helpers.BadRequest("invalid_actions", err.Error())
@@ -60,6 +60,11 @@ func (h *CustomBotsHandler) HandleCustomBotInteraction(c *fiber.Ctx) error {
 		}
 	} else if interaction.Type == discordgo.InteractionApplicationCommand {
 		handle = true
+	} else if interaction.Type == discordgo.InteractionModalSubmit {
+		data := interaction.ModalSubmitData()
+		if strings.HasPrefix(data.CustomID, "action:") {
+			handle = true
+		}
 	}
 
 	if handle {
@@ -0,0 +1,91 @@
+package delayed_actions
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/guregu/null.v4"
+)
+
+type DelayedActionsHandler struct {
+	pg *postgres.PostgresStore
+	am *access.AccessManager
+}
+
+func New(pg *postgres.PostgresStore, am *access.AccessManager) *DelayedActionsHandler {
+	return &DelayedActionsHandler{
+		pg: pg,
+		am: am,
+	}
+}
+
+func (h *DelayedActionsHandler) HandleListDelayedActions(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	delayedActions, err := h.pg.Q.GetDelayedActions(c.Context(), guildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get delayed actions")
+		return err
+	}
+
+	res := make([]wire.DelayedActionWire, len(delayedActions))
+	for i, delayedAction := range delayedActions {
+		res[i] = delayedActionModelToWire(delayedAction)
+	}
+
+	return c.JSON(wire.DelayedActionListResponseWire{
+		Success: true,
+		Data:    res,
+	})
+}
+
+// HandleDeleteDelayedAction cancels a pending delayed action before it's executed.
+func (h *DelayedActionsHandler) HandleDeleteDelayedAction(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+	delayedActionID := c.Params("delayedActionID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	_, err := h.pg.Q.DeleteDelayedAction(c.Context(), pgmodel.DeleteDelayedActionParams{
+		ID:      delayedActionID,
+		GuildID: guildID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_delayed_action", "The delayed action does not exist or has already been executed.")
+		}
+		log.Error().Err(err).Msg("Failed to delete delayed action")
+		return err
+	}
+
+	return c.JSON(wire.DelayedActionDeleteResponseWire{
+		Success: true,
+		Data:    struct{}{},
+	})
+}
+
+func delayedActionModelToWire(model pgmodel.DelayedAction) wire.DelayedActionWire {
+	return wire.DelayedActionWire{
+		ID:        model.ID,
+		GuildID:   model.GuildID,
+		ChannelID: model.ChannelID,
+		MessageID: null.String{NullString: model.MessageID},
+		SourceID:  model.SourceID,
+		UserID:    model.UserID,
+		Actions:   model.Actions,
+		ExecuteAt: model.ExecuteAt,
+		CreatedAt: model.CreatedAt,
+	}
+}
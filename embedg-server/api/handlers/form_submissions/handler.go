@@ -0,0 +1,169 @@
+package form_submissions
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/guregu/null.v4"
+)
+
+type FormSubmissionsHandler struct {
+	pg *postgres.PostgresStore
+	am *access.AccessManager
+}
+
+func New(pg *postgres.PostgresStore, am *access.AccessManager) *FormSubmissionsHandler {
+	return &FormSubmissionsHandler{
+		pg: pg,
+		am: am,
+	}
+}
+
+func (h *FormSubmissionsHandler) HandleListFormSubmissions(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	submissions, err := h.pg.Q.GetFormSubmissions(c.Context(), pgmodel.GetFormSubmissionsParams{
+		GuildID: guildID,
+		Limit:   int32(limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get form submissions")
+		return err
+	}
+
+	res := make([]wire.FormSubmissionWire, len(submissions))
+	for i, submission := range submissions {
+		res[i] = formSubmissionModelToWire(submission)
+	}
+
+	return c.JSON(wire.FormSubmissionListResponseWire{
+		Success: true,
+		Data:    res,
+	})
+}
+
+// HandleExportFormSubmissions returns all form submissions of the guild as a CSV file.
+// Every field name that appears in any of the submissions gets its own column.
+func (h *FormSubmissionsHandler) HandleExportFormSubmissions(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	submissions, err := h.pg.Q.GetAllFormSubmissions(c.Context(), guildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get form submissions")
+		return err
+	}
+
+	fieldNames := []string{}
+	seenFieldNames := map[string]bool{}
+
+	rows := make([]map[string]string, len(submissions))
+	for i, submission := range submissions {
+		rows[i] = make(map[string]string)
+
+		for _, field := range parseFormSubmissionFields(submission.Fields) {
+			if !seenFieldNames[field.Name] {
+				seenFieldNames[field.Name] = true
+				fieldNames = append(fieldNames, field.Name)
+			}
+			rows[i][field.Name] = field.Value
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := append([]string{"id", "created_at", "title", "user_id", "channel_id", "message_id"}, fieldNames...)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for i, submission := range submissions {
+		record := []string{
+			submission.ID,
+			submission.CreatedAt.Format(time.RFC3339),
+			submission.Title,
+			submission.UserID,
+			submission.ChannelID,
+			submission.MessageID.String,
+		}
+		for _, name := range fieldNames {
+			record = append(record, rows[i][name])
+		}
+
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"form-submissions-%s.csv\"", guildID))
+	return c.Send(buf.Bytes())
+}
+
+func parseFormSubmissionFields(raw []byte) []actions.FormSubmissionField {
+	var fields []actions.FormSubmissionField
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal form submission fields")
+	}
+
+	return fields
+}
+
+func formSubmissionModelToWire(model pgmodel.FormSubmission) wire.FormSubmissionWire {
+	fields := parseFormSubmissionFields(model.Fields)
+
+	res := wire.FormSubmissionWire{
+		ID:        model.ID,
+		GuildID:   model.GuildID,
+		ChannelID: model.ChannelID,
+		MessageID: null.NewString(model.MessageID.String, model.MessageID.Valid),
+		UserID:    model.UserID,
+		Title:     model.Title,
+		Fields:    make([]wire.FormSubmissionFieldWire, len(fields)),
+		CreatedAt: model.CreatedAt,
+	}
+
+	for i, field := range fields {
+		res.Fields[i] = wire.FormSubmissionFieldWire{
+			Name:  field.Name,
+			Label: field.Label,
+			Value: field.Value,
+		}
+	}
+
+	return res
+}
@@ -70,6 +70,11 @@ func (h *PremiumHandler) HandleGetFeatures(c *fiber.Ctx) error {
 			MaxImageUploadSize:        features.MaxImageUploadSize,
 			MaxScheduledMessages:      features.MaxScheduledMessages,
 			PeriodicScheduledMessages: features.PeriodicScheduledMessages,
+			HTTPRequestActions:        features.HTTPRequestActions,
+			ActionLogRetentionDays:    features.ActionLogRetentionDays,
+			MaxActionSets:             features.MaxActionSets,
+			MaxTemplateSnippetDepth:   features.MaxTemplateSnippetDepth,
+			MaxTemplateSnippetsSize:   features.MaxTemplateSnippetsSize,
 		},
 	})
 }
@@ -2,9 +2,12 @@ package saved_messages
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/session"
@@ -71,6 +74,10 @@ func (h *SavedMessagesHandler) HandleCreateSavedMessage(c *fiber.Ctx, req wire.S
 		}
 	}
 
+	if err := h.validateBookPages(c, guildID, req.Data); err != nil {
+		return err
+	}
+
 	message, err := h.pg.Q.InsertSavedMessage(c.Context(), pgmodel.InsertSavedMessageParams{
 		ID:          util.UniqueID(),
 		CreatorID:   session.UserID,
@@ -102,6 +109,10 @@ func (h *SavedMessagesHandler) HandleUpdateSavedMessage(c *fiber.Ctx, req wire.S
 		}
 	}
 
+	if err := h.validateBookPages(c, guildID, req.Data); err != nil {
+		return err
+	}
+
 	var message pgmodel.SavedMessage
 	var err error
 	if guildID != "" {
@@ -186,6 +197,12 @@ func (h *SavedMessagesHandler) HandleImportSavedMessages(c *fiber.Ctx, req wire.
 		}
 	}
 
+	for _, msg := range req.Messages {
+		if err := h.validateBookPages(c, guildID, msg.Data); err != nil {
+			return err
+		}
+	}
+
 	res := make([]wire.SavedMessageWire, len(req.Messages))
 
 	for i, msg := range req.Messages {
@@ -211,6 +228,41 @@ func (h *SavedMessagesHandler) HandleImportSavedMessages(c *fiber.Ctx, req wire.
 	})
 }
 
+// validateBookPages makes sure that all pages of a book are saved messages of the same server.
+func (h *SavedMessagesHandler) validateBookPages(c *fiber.Ctx, guildID string, raw json.RawMessage) error {
+	data := actions.MessageWithActions{}
+	if err := json.Unmarshal(raw, &data); err != nil || len(data.Pages) == 0 {
+		return nil
+	}
+
+	if guildID == "" {
+		return helpers.BadRequest("invalid_book", "Books can only be saved for a server.")
+	}
+
+	if len(data.Pages) > actions.MaxBookPages {
+		return helpers.BadRequest("invalid_book", fmt.Sprintf("Books can't have more than %d pages.", actions.MaxBookPages))
+	}
+
+	for _, page := range data.Pages {
+		if page == "" {
+			return helpers.BadRequest("invalid_book", "Pages of books can't be empty.")
+		}
+
+		_, err := h.pg.Q.GetSavedMessageForGuild(c.Context(), pgmodel.GetSavedMessageForGuildParams{
+			GuildID: sql.NullString{String: guildID, Valid: true},
+			ID:      page,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return helpers.BadRequest("invalid_book", fmt.Sprintf("The page %s of the book does not exist.", page))
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func savedMessageModelToWire(model pgmodel.SavedMessage) wire.SavedMessageWire {
 	return wire.SavedMessageWire{
 		ID:          model.ID,
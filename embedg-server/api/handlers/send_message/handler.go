@@ -2,6 +2,7 @@ package send_message
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 
@@ -67,7 +68,9 @@ func (h *SendMessageHandler) HandleSendMessageToChannel(c *fiber.Ctx, req wire.M
 		"SEND_MESSAGE", features.MaxTemplateOps,
 		template.NewGuildProvider(h.bot.State, channel.GuildID, nil),
 		template.NewChannelProvider(h.bot.State, req.ChannelID, nil),
+		template.NewStateProvider(h.bot.State, channel.GuildID),
 		template.NewKVProvider(channel.GuildID, h.pg, features.MaxKVKeys),
+		template.NewSnippetProvider(channel.GuildID, h.pg, features.MaxTemplateSnippetDepth, features.MaxTemplateSnippetsSize),
 	)
 
 	data := &actions.MessageWithActions{}
@@ -76,6 +79,21 @@ func (h *SendMessageHandler) HandleSendMessageToChannel(c *fiber.Ctx, req wire.M
 		return err
 	}
 
+	if len(data.Pages) != 0 {
+		// The navigation of books reads the pages from the saved message
+		if !req.SavedMessageID.Valid {
+			return helpers.BadRequest("invalid_book", "Books can only be sent from a saved message of the server.")
+		}
+
+		data, err = h.bot.ActionHandler.OpenBook(c.Context(), channel.GuildID, req.SavedMessageID.String, data)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return helpers.BadRequest("invalid_book", "A page of the book does not exist.")
+			}
+			return fmt.Errorf("Failed to open book: %w", err)
+		}
+	}
+
 	err = templates.ParseAndExecuteMessage(data)
 	if err != nil {
 		return fmt.Errorf("Failed to parse and execute message template: %w", err)
@@ -115,6 +133,11 @@ func (h *SendMessageHandler) HandleSendMessageToChannel(c *fiber.Ctx, req wire.M
 		return helpers.BadRequest("invalid_actions", err.Error())
 	}
 
+	err = h.actionParser.CheckLibraryActionSets(data.Actions, session.UserID, req.GuildID, req.ChannelID)
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", err.Error())
+	}
+
 	var msg *discordgo.Message
 	if req.MessageID.Valid {
 		msg, err = h.bot.EditMessageInChannel(c.Context(), req.ChannelID, req.MessageID.String, &discordgo.WebhookEdit{
@@ -143,6 +166,23 @@ func (h *SendMessageHandler) HandleSendMessageToChannel(c *fiber.Ctx, req wire.M
 		return err
 	}
 
+	err = h.actionParser.CreatePollForMessage(c.Context(), data.Actions, req.GuildID, msg.ChannelID, msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create poll for message")
+		return err
+	}
+
+	err = h.actionParser.CreateReactionsForMessage(c.Context(), data.Reactions, msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create reactions for message")
+		return err
+	}
+
+	err = h.bot.AddReactionsToMessage(c.Context(), msg.ChannelID, msg.ID, data.Reactions)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to add reactions to message")
+	}
+
 	return c.JSON(wire.MessageSendResponseWire{
 		Success: true,
 		Data: wire.MessageSendResponseDataWire{
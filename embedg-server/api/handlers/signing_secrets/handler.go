@@ -0,0 +1,69 @@
+package signing_secrets
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+type SigningSecretsHandler struct {
+	pg *postgres.PostgresStore
+	am *access.AccessManager
+}
+
+func New(pg *postgres.PostgresStore, am *access.AccessManager) *SigningSecretsHandler {
+	return &SigningSecretsHandler{
+		pg: pg,
+		am: am,
+	}
+}
+
+func (h *SigningSecretsHandler) HandleGetSigningSecret(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	secret, err := h.pg.GetOrCreateGuildSigningSecret(c.Context(), guildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get signing secret")
+		return err
+	}
+
+	return c.JSON(wire.SigningSecretGetResponseWire{
+		Success: true,
+		Data:    signingSecretModelToWire(secret),
+	})
+}
+
+// HandleRegenerateSigningSecret replaces the secret, requests that are signed with the old secret can't be verified anymore.
+func (h *SigningSecretsHandler) HandleRegenerateSigningSecret(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	secret, err := h.pg.RegenerateGuildSigningSecret(c.Context(), guildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to regenerate signing secret")
+		return err
+	}
+
+	return c.JSON(wire.SigningSecretRegenerateResponseWire{
+		Success: true,
+		Data:    signingSecretModelToWire(secret),
+	})
+}
+
+func signingSecretModelToWire(model pgmodel.GuildSigningSecret) wire.SigningSecretWire {
+	return wire.SigningSecretWire{
+		GuildID:   model.GuildID,
+		Secret:    model.Secret,
+		CreatedAt: model.CreatedAt,
+	}
+}
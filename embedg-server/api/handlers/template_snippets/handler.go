@@ -0,0 +1,227 @@
+package template_snippets
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/template"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/bot"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/store"
+	"github.com/merlinfuchs/embed-generator/embedg-server/util"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/guregu/null.v4"
+)
+
+type TemplateSnippetsHandler struct {
+	pg        *postgres.PostgresStore
+	bot       *bot.Bot
+	am        *access.AccessManager
+	planStore store.PlanStore
+}
+
+func New(pg *postgres.PostgresStore, bot *bot.Bot, am *access.AccessManager, planStore store.PlanStore) *TemplateSnippetsHandler {
+	return &TemplateSnippetsHandler{
+		pg:        pg,
+		bot:       bot,
+		am:        am,
+		planStore: planStore,
+	}
+}
+
+func (h *TemplateSnippetsHandler) HandleListTemplateSnippets(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	snippets, err := h.pg.Q.GetGuildTemplateSnippets(c.Context(), guildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get guild template snippets")
+		return err
+	}
+
+	res := make([]wire.TemplateSnippetWire, len(snippets))
+	for i, snippet := range snippets {
+		res[i] = templateSnippetModelToWire(snippet)
+	}
+
+	return c.JSON(wire.TemplateSnippetListResponseWire{
+		Success: true,
+		Data:    res,
+	})
+}
+
+func (h *TemplateSnippetsHandler) HandleGetTemplateSnippet(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	snippet, err := h.pg.Q.GetGuildTemplateSnippet(c.Context(), pgmodel.GetGuildTemplateSnippetParams{
+		ID:      c.Params("snippetID"),
+		GuildID: guildID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_template_snippet", "The template snippet does not exist.")
+		}
+		return err
+	}
+
+	return c.JSON(wire.TemplateSnippetGetResponseWire{
+		Success: true,
+		Data:    templateSnippetModelToWire(snippet),
+	})
+}
+
+func (h *TemplateSnippetsHandler) HandleCreateTemplateSnippet(c *fiber.Ctx, req wire.TemplateSnippetCreateRequestWire) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	if err := h.validateTemplateSnippet(c, guildID, "", req.Name, req.Content); err != nil {
+		return err
+	}
+
+	snippet, err := h.pg.Q.InsertGuildTemplateSnippet(c.Context(), pgmodel.InsertGuildTemplateSnippetParams{
+		ID:          util.UniqueID(),
+		GuildID:     guildID,
+		Name:        req.Name,
+		Description: req.Description.NullString,
+		Content:     req.Content,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to insert guild template snippet")
+		return err
+	}
+
+	return c.JSON(wire.TemplateSnippetCreateResponseWire{
+		Success: true,
+		Data:    templateSnippetModelToWire(snippet),
+	})
+}
+
+// HandleUpdateTemplateSnippet changes the snippet for all templates that use it.
+func (h *TemplateSnippetsHandler) HandleUpdateTemplateSnippet(c *fiber.Ctx, req wire.TemplateSnippetUpdateRequestWire) error {
+	guildID := c.Params("guildID")
+	snippetID := c.Params("snippetID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	if err := h.validateTemplateSnippet(c, guildID, snippetID, req.Name, req.Content); err != nil {
+		return err
+	}
+
+	snippet, err := h.pg.Q.UpdateGuildTemplateSnippet(c.Context(), pgmodel.UpdateGuildTemplateSnippetParams{
+		ID:          snippetID,
+		GuildID:     guildID,
+		Name:        req.Name,
+		Description: req.Description.NullString,
+		Content:     req.Content,
+		UpdatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_template_snippet", "The template snippet does not exist.")
+		}
+		log.Error().Err(err).Msg("Failed to update guild template snippet")
+		return err
+	}
+
+	return c.JSON(wire.TemplateSnippetUpdateResponseWire{
+		Success: true,
+		Data:    templateSnippetModelToWire(snippet),
+	})
+}
+
+func (h *TemplateSnippetsHandler) HandleDeleteTemplateSnippet(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	_, err := h.pg.Q.DeleteGuildTemplateSnippet(c.Context(), pgmodel.DeleteGuildTemplateSnippetParams{
+		ID:      c.Params("snippetID"),
+		GuildID: guildID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_template_snippet", "The template snippet does not exist.")
+		}
+		log.Error().Err(err).Msg("Failed to delete guild template snippet")
+		return err
+	}
+
+	return c.JSON(wire.TemplateSnippetDeleteResponseWire{
+		Success: true,
+		Data:    struct{}{},
+	})
+}
+
+// validateTemplateSnippet makes sure that the name is unique, the content is a valid template
+// and all snippets of the guild together stay within the size limit of the plan.
+func (h *TemplateSnippetsHandler) validateTemplateSnippet(c *fiber.Ctx, guildID string, snippetID string, name string, content string) error {
+	features, err := h.planStore.GetPlanFeaturesForGuild(c.Context(), guildID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := h.pg.Q.GetGuildTemplateSnippets(c.Context(), guildID)
+	if err != nil {
+		return err
+	}
+
+	size := len(content)
+	for _, snippet := range existing {
+		if snippet.ID == snippetID {
+			continue
+		}
+		if snippet.Name == name {
+			return helpers.BadRequest("duplicate_template_snippet", "A template snippet with this name already exists.")
+		}
+		size += len(snippet.Content)
+	}
+
+	if size > template.MaxSnippetsSize(features.MaxTemplateSnippetsSize) {
+		return helpers.Forbidden("insufficient_plan", "You have reached the maximum total size of template snippets for your plan!")
+	}
+
+	templates := template.NewContext(
+		"TEMPLATE_SNIPPET", features.MaxTemplateOps,
+		template.NewStateProvider(h.bot.State, guildID),
+		template.NewKVProvider(guildID, h.pg, features.MaxKVKeys),
+		template.NewSnippetProvider(guildID, h.pg, features.MaxTemplateSnippetDepth, features.MaxTemplateSnippetsSize),
+	)
+	if err := templates.CheckSyntax(content); err != nil {
+		return helpers.BadRequest("invalid_template", err.Error())
+	}
+
+	return nil
+}
+
+func templateSnippetModelToWire(model pgmodel.GuildTemplateSnippet) wire.TemplateSnippetWire {
+	return wire.TemplateSnippetWire{
+		ID:          model.ID,
+		GuildID:     model.GuildID,
+		Name:        model.Name,
+		Description: null.String{NullString: model.Description},
+		Content:     model.Content,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
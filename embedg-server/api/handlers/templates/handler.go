@@ -0,0 +1,320 @@
+package templates
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/template"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/variables"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/session"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/bot"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/overlay"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/model"
+	"github.com/merlinfuchs/embed-generator/embedg-server/store"
+)
+
+type TemplatesHandler struct {
+	pg        *postgres.PostgresStore
+	bot       *bot.Bot
+	am        *access.AccessManager
+	planStore store.PlanStore
+}
+
+func New(pg *postgres.PostgresStore, bot *bot.Bot, am *access.AccessManager, planStore store.PlanStore) *TemplatesHandler {
+	return &TemplatesHandler{
+		pg:        pg,
+		bot:       bot,
+		am:        am,
+		planStore: planStore,
+	}
+}
+
+// HandleValidateTemplates returns all problems in the templates of the message without executing them.
+func (h *TemplatesHandler) HandleValidateTemplates(c *fiber.Ctx, req wire.TemplateValidateRequestWire) error {
+	if err := h.am.CheckGuildAccessForRequest(c, req.GuildID); err != nil {
+		return err
+	}
+
+	data := &actions.MessageWithActions{}
+	err := json.Unmarshal(req.Data, data)
+	if err != nil {
+		return helpers.BadRequest("invalid_message", "The message is not valid.")
+	}
+
+	features, err := h.planStore.GetPlanFeaturesForGuild(c.Context(), req.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not get plan features: %w", err)
+	}
+
+	templates := template.NewContext(
+		"VALIDATE", features.MaxTemplateOps,
+		template.NewStateProvider(h.bot.State, req.GuildID),
+		template.NewKVProvider(req.GuildID, h.pg, features.MaxKVKeys),
+		template.NewSnippetProvider(req.GuildID, h.pg, features.MaxTemplateSnippetDepth, features.MaxTemplateSnippetsSize),
+	)
+
+	res := wire.TemplateValidateResponseDataWire{
+		Errors:   []wire.TemplateIssueWire{},
+		Warnings: []wire.TemplateIssueWire{},
+	}
+	for _, issue := range templates.LintMessage(data) {
+		issueWire := wire.TemplateIssueWire{
+			Path:    issue.Path,
+			Message: issue.Message,
+			Line:    issue.Line,
+			Column:  issue.Column,
+		}
+
+		if issue.Severity == template.LintSeverityError {
+			res.Errors = append(res.Errors, issueWire)
+		} else {
+			res.Warnings = append(res.Warnings, issueWire)
+		}
+	}
+
+	return c.JSON(wire.TemplateValidateResponseWire{
+		Success: true,
+		Data:    res,
+	})
+}
+
+// HandleRenderTemplates returns the message as it would be shown to the member after all templates have been executed.
+// The interaction is made up from the request, nothing is sent to Discord and changes to KV entries are discarded.
+// Either the data of the message or the id of a message that has been saved for the server can be rendered.
+func (h *TemplatesHandler) HandleRenderTemplates(c *fiber.Ctx, req wire.TemplateRenderRequestWire) error {
+	session := c.Locals("session").(*session.Session)
+
+	if err := h.am.CheckChannelAccessForRequest(c, req.ChannelID); err != nil {
+		return err
+	}
+
+	channel, err := h.bot.State.Channel(req.ChannelID)
+	if err != nil {
+		return fmt.Errorf("Failed to get channel: %w", err)
+	}
+
+	rawData := req.Data
+	if req.SavedMessageID.Valid {
+		msg, err := h.pg.Q.GetSavedMessageForGuild(c.Context(), pgmodel.GetSavedMessageForGuildParams{
+			GuildID: sql.NullString{String: channel.GuildID, Valid: true},
+			ID:      req.SavedMessageID.String,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return helpers.NotFound("unknown_message", "The message does not exist.")
+			}
+			return err
+		}
+		rawData = msg.Data
+	}
+
+	data := &actions.MessageWithActions{}
+	err = json.Unmarshal(rawData, data)
+	if err != nil {
+		return helpers.BadRequest("invalid_message", "The message is not valid.")
+	}
+
+	features, err := h.planStore.GetPlanFeaturesForGuild(c.Context(), channel.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not get plan features: %w", err)
+	}
+
+	if len(req.KV) > features.MaxKVKeys {
+		return helpers.BadRequest("too_many_kv_keys", fmt.Sprintf("You can't override more than %d keys.", features.MaxKVKeys))
+	}
+	for key, value := range req.KV {
+		if len(key) > template.MaxKVKeyLength {
+			return helpers.BadRequest("invalid_kv_key", fmt.Sprintf("Keys can't be longer than %d characters.", template.MaxKVKeyLength))
+		}
+		if len(value) > template.MaxKVValueLength {
+			return helpers.BadRequest("invalid_kv_value", fmt.Sprintf("The value of the key %s can't be longer than %d characters.", key, template.MaxKVValueLength))
+		}
+	}
+
+	interaction, err := h.previewInteraction(session.UserID, channel, req)
+	if err != nil {
+		return err
+	}
+
+	kvStore := overlay.NewKVEntryStore(h.pg)
+	for key, value := range req.KV {
+		err := kvStore.SetKVEntry(c.Context(), model.KVEntry{
+			Key:       key,
+			GuildID:   channel.GuildID,
+			Value:     value,
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	variables.NewContext(
+		variables.NewInteractionVariables(interaction),
+		variables.NewGuildVariables(channel.GuildID, h.bot.State, nil),
+		variables.NewChannelVariables(channel.ID, h.bot.State, channel),
+	).FillMessage(data)
+
+	templates := template.NewContext(
+		"RENDER", features.MaxTemplateOps,
+		template.NewInteractionProvider(h.bot.State, interaction),
+		template.NewStateProvider(h.bot.State, channel.GuildID),
+		template.NewKVProvider(channel.GuildID, kvStore, features.MaxKVKeys),
+		template.NewSnippetProvider(channel.GuildID, h.pg, features.MaxTemplateSnippetDepth, features.MaxTemplateSnippetsSize),
+	)
+
+	if err := templates.ParseAndExecuteMessage(data); err != nil {
+		return helpers.BadRequest("invalid_template", err.Error())
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(wire.TemplateRenderResponseWire{
+		Success: true,
+		Data: wire.TemplateRenderResponseDataWire{
+			Data: raw,
+		},
+	})
+}
+
+// previewInteraction makes up the interaction that the message is rendered for.
+// The member is taken from the state if it exists, otherwise a member with only the given roles is used.
+func (h *TemplatesHandler) previewInteraction(userID string, channel *discordgo.Channel, req wire.TemplateRenderRequestWire) (*discordgo.Interaction, error) {
+	if req.Member.UserID.Valid {
+		userID = req.Member.UserID.String
+	}
+
+	member := &discordgo.Member{
+		GuildID: channel.GuildID,
+		User:    &discordgo.User{ID: userID},
+	}
+	if m, err := h.bot.State.Member(channel.GuildID, userID); err == nil {
+		// Copy the member, so the state isn't changed
+		copied := *m
+		member = &copied
+	}
+
+	if req.Member.Roles != nil {
+		member.Roles = req.Member.Roles
+	}
+
+	if req.Member.Permissions != "" {
+		permissions, err := strconv.ParseInt(req.Member.Permissions, 10, 64)
+		if err != nil {
+			return nil, helpers.BadRequest("invalid_permissions", "The permissions of the member are not valid.")
+		}
+		member.Permissions = permissions
+	} else if permissions, err := h.bot.State.UserChannelPermissions(userID, channel.ID); err == nil {
+		member.Permissions = permissions
+	}
+
+	interaction := &discordgo.Interaction{
+		Type:      discordgo.InteractionMessageComponent,
+		GuildID:   channel.GuildID,
+		ChannelID: channel.ID,
+		Member:    member,
+		Locale:    discordgo.Locale(req.Member.Locale),
+		Data: discordgo.MessageComponentInteractionData{
+			ComponentType: discordgo.ButtonComponent,
+		},
+	}
+
+	if req.Command != nil {
+		data, err := h.previewCommandData(channel.GuildID, req.Command)
+		if err != nil {
+			return nil, err
+		}
+
+		interaction.Type = discordgo.InteractionApplicationCommand
+		interaction.Data = *data
+	}
+
+	return interaction, nil
+}
+
+// previewCommandData converts the options of the command to the types that Discord uses and resolves users, roles and channels from the state.
+func (h *TemplatesHandler) previewCommandData(guildID string, command *wire.TemplateRenderCommandWire) (*discordgo.ApplicationCommandInteractionData, error) {
+	data := &discordgo.ApplicationCommandInteractionData{
+		Name:        command.Name,
+		CommandType: discordgo.ChatApplicationCommand,
+		Options:     make([]*discordgo.ApplicationCommandInteractionDataOption, len(command.Options)),
+		Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+			Users:       make(map[string]*discordgo.User),
+			Members:     make(map[string]*discordgo.Member),
+			Roles:       make(map[string]*discordgo.Role),
+			Channels:    make(map[string]*discordgo.Channel),
+			Attachments: make(map[string]*discordgo.MessageAttachment),
+		},
+	}
+
+	for i, option := range command.Options {
+		optionType := discordgo.ApplicationCommandOptionType(option.Type)
+		invalid := helpers.BadRequest("invalid_command_option", fmt.Sprintf("The value of the option %s is not valid.", option.Name))
+
+		var value interface{}
+		switch optionType {
+		case discordgo.ApplicationCommandOptionString:
+			value = option.Value
+		case discordgo.ApplicationCommandOptionInteger:
+			v, err := strconv.ParseInt(option.Value, 10, 64)
+			if err != nil {
+				return nil, invalid
+			}
+			value = float64(v)
+		case discordgo.ApplicationCommandOptionNumber:
+			v, err := strconv.ParseFloat(option.Value, 64)
+			if err != nil {
+				return nil, invalid
+			}
+			value = v
+		case discordgo.ApplicationCommandOptionBoolean:
+			v, err := strconv.ParseBool(option.Value)
+			if err != nil {
+				return nil, invalid
+			}
+			value = v
+		case discordgo.ApplicationCommandOptionUser:
+			value = option.Value
+			data.Resolved.Users[option.Value] = &discordgo.User{ID: option.Value}
+			if member, err := h.bot.State.Member(guildID, option.Value); err == nil && member.User != nil {
+				data.Resolved.Users[option.Value] = member.User
+				data.Resolved.Members[option.Value] = member
+			}
+		case discordgo.ApplicationCommandOptionRole:
+			value = option.Value
+			if role, err := h.bot.State.Role(guildID, option.Value); err == nil {
+				data.Resolved.Roles[option.Value] = role
+			}
+		case discordgo.ApplicationCommandOptionChannel:
+			value = option.Value
+			if channel, err := h.bot.State.Channel(option.Value); err == nil && channel.GuildID == guildID {
+				data.Resolved.Channels[option.Value] = channel
+			}
+		default:
+			return nil, helpers.BadRequest("invalid_command_option", fmt.Sprintf("The type of the option %s is not supported.", option.Name))
+		}
+
+		data.Options[i] = &discordgo.ApplicationCommandInteractionDataOption{
+			Name:  option.Name,
+			Type:  optionType,
+			Value: value,
+		}
+	}
+
+	return data, nil
+}
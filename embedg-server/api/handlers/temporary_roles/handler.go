@@ -0,0 +1,103 @@
+package temporary_roles
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/access"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/wire"
+	"github.com/merlinfuchs/embed-generator/embedg-server/bot"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+type TemporaryRolesHandler struct {
+	pg  *postgres.PostgresStore
+	bot *bot.Bot
+	am  *access.AccessManager
+}
+
+func New(pg *postgres.PostgresStore, bot *bot.Bot, am *access.AccessManager) *TemporaryRolesHandler {
+	return &TemporaryRolesHandler{
+		pg:  pg,
+		bot: bot,
+		am:  am,
+	}
+}
+
+func (h *TemporaryRolesHandler) HandleListTemporaryRoles(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	temporaryRoles, err := h.pg.Q.GetTemporaryRoles(c.Context(), guildID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get temporary roles")
+		return err
+	}
+
+	res := make([]wire.TemporaryRoleWire, len(temporaryRoles))
+	for i, temporaryRole := range temporaryRoles {
+		res[i] = temporaryRoleModelToWire(temporaryRole)
+	}
+
+	return c.JSON(wire.TemporaryRoleListResponseWire{
+		Success: true,
+		Data:    res,
+	})
+}
+
+// HandleRevokeTemporaryRole removes the role from the member before the grant has expired.
+func (h *TemporaryRolesHandler) HandleRevokeTemporaryRole(c *fiber.Ctx) error {
+	guildID := c.Params("guildID")
+	temporaryRoleID := c.Params("temporaryRoleID")
+
+	if err := h.am.CheckGuildAccessForRequest(c, guildID); err != nil {
+		return err
+	}
+
+	temporaryRole, err := h.pg.Q.DeleteTemporaryRole(c.Context(), pgmodel.DeleteTemporaryRoleParams{
+		ID:      temporaryRoleID,
+		GuildID: guildID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NotFound("unknown_temporary_role", "The temporary role does not exist or has already expired.")
+		}
+		log.Error().Err(err).Msg("Failed to delete temporary role")
+		return err
+	}
+
+	session, err := h.bot.GetSessionForGuild(c.Context(), guildID)
+	if err != nil {
+		return err
+	}
+
+	err = session.GuildMemberRoleRemove(guildID, temporaryRole.UserID, temporaryRole.RoleID)
+	if err != nil && !util.IsDiscordRestErrorCode(err, discordgo.ErrCodeUnknownMember, discordgo.ErrCodeUnknownRole) {
+		log.Error().Err(err).Msg("Failed to remove temporary role")
+		return err
+	}
+
+	return c.JSON(wire.TemporaryRoleRevokeResponseWire{
+		Success: true,
+		Data:    struct{}{},
+	})
+}
+
+func temporaryRoleModelToWire(model pgmodel.TemporaryRole) wire.TemporaryRoleWire {
+	return wire.TemporaryRoleWire{
+		ID:        model.ID,
+		GuildID:   model.GuildID,
+		UserID:    model.UserID,
+		RoleID:    model.RoleID,
+		ExpiresAt: model.ExpiresAt,
+		CreatedAt: model.CreatedAt,
+	}
+}
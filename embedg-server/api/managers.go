@@ -28,7 +28,7 @@ func createManagers(stores *stores, bot *bot.Bot) *managers {
 	premiumManager := premium.New(stores.pg, bot)
 
 	actionParser := parser.New(accessManager, stores.pg, bot.State)
-	actionHandler := handler.New(stores.pg, actionParser, premiumManager)
+	actionHandler := handler.New(stores.pg, actionParser, premiumManager, bot)
 
 	customBots := custom_bots.NewCustomBotManager(stores.pg, actionHandler)
 	scheduledMessages := scheduled_messages.NewScheduledMessageManager(stores.pg, actionParser, bot, premiumManager)
@@ -104,8 +104,12 @@ func New(pg *postgres.PostgresStore, bot *bot.Bot) *PremiumManager {
 	}
 
 	defaultPlanFeatures := model.PlanFeatures{
-		MaxSavedMessages:       25,
-		MaxActionsPerComponent: 2,
+		MaxSavedMessages:        25,
+		MaxActionsPerComponent:  2,
+		ActionLogRetentionDays:  1,
+		MaxActionSets:           10,
+		MaxTemplateSnippetDepth: 3,
+		MaxTemplateSnippetsSize: 16384,
 	}
 	for _, plan := range plans {
 		if plan.Default {
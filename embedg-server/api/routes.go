@@ -7,10 +7,15 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/filesystem"
 	embedgapp "github.com/merlinfuchs/embed-generator/embedg-app"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/action_logs"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/action_sets"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/action_simulator"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/assistant"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/auth"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/custom_bots"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/delayed_actions"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/embed_links"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/form_submissions"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/guilds"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/images"
 	premium_handler "github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/premium"
@@ -18,6 +23,10 @@ import (
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/scheduled_messages"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/send_message"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/shared_messages"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/signing_secrets"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/template_snippets"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/templates"
+	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/temporary_roles"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/handlers/users"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/helpers"
 	"github.com/merlinfuchs/embed-generator/embedg-server/api/session"
@@ -70,12 +79,52 @@ func registerRoutes(app *fiber.App, stores *stores, bot *bot.Bot, managers *mana
 	guildsGroup.Get("/:guildID/stickers", guildsHanlder.HandleListGuildStickers)
 	guildsGroup.Get("/:guildID/branding", guildsHanlder.HandleGetGuildBranding)
 
+	formSubmissionsHandler := form_submissions.New(stores.pg, managers.access)
+	guildsGroup.Get("/:guildID/form-submissions", formSubmissionsHandler.HandleListFormSubmissions)
+	guildsGroup.Get("/:guildID/form-submissions/export", formSubmissionsHandler.HandleExportFormSubmissions)
+
+	delayedActionsHandler := delayed_actions.New(stores.pg, managers.access)
+	guildsGroup.Get("/:guildID/delayed-actions", delayedActionsHandler.HandleListDelayedActions)
+	guildsGroup.Delete("/:guildID/delayed-actions/:delayedActionID", delayedActionsHandler.HandleDeleteDelayedAction)
+
+	actionLogsHandler := action_logs.New(stores.pg, managers.access)
+	guildsGroup.Get("/:guildID/action-logs", actionLogsHandler.HandleListActionLogs)
+
+	actionSetsHandler := action_sets.New(stores.pg, managers.access, managers.premium, managers.actionParser)
+	guildsGroup.Get("/:guildID/action-sets", actionSetsHandler.HandleListActionSets)
+	guildsGroup.Post("/:guildID/action-sets", helpers.WithRequestBodyValidated(actionSetsHandler.HandleCreateActionSet))
+	guildsGroup.Get("/:guildID/action-sets/:actionSetID", actionSetsHandler.HandleGetActionSet)
+	guildsGroup.Put("/:guildID/action-sets/:actionSetID", helpers.WithRequestBodyValidated(actionSetsHandler.HandleUpdateActionSet))
+	guildsGroup.Delete("/:guildID/action-sets/:actionSetID", actionSetsHandler.HandleDeleteActionSet)
+
+	templateSnippetsHandler := template_snippets.New(stores.pg, bot, managers.access, managers.premium)
+	guildsGroup.Get("/:guildID/template-snippets", templateSnippetsHandler.HandleListTemplateSnippets)
+	guildsGroup.Post("/:guildID/template-snippets", helpers.WithRequestBodyValidated(templateSnippetsHandler.HandleCreateTemplateSnippet))
+	guildsGroup.Get("/:guildID/template-snippets/:snippetID", templateSnippetsHandler.HandleGetTemplateSnippet)
+	guildsGroup.Put("/:guildID/template-snippets/:snippetID", helpers.WithRequestBodyValidated(templateSnippetsHandler.HandleUpdateTemplateSnippet))
+	guildsGroup.Delete("/:guildID/template-snippets/:snippetID", templateSnippetsHandler.HandleDeleteTemplateSnippet)
+
+	temporaryRolesHandler := temporary_roles.New(stores.pg, bot, managers.access)
+	guildsGroup.Get("/:guildID/temporary-roles", temporaryRolesHandler.HandleListTemporaryRoles)
+	guildsGroup.Delete("/:guildID/temporary-roles/:temporaryRoleID", temporaryRolesHandler.HandleRevokeTemporaryRole)
+
+	signingSecretsHandler := signing_secrets.New(stores.pg, managers.access)
+	guildsGroup.Get("/:guildID/signing-secret", signingSecretsHandler.HandleGetSigningSecret)
+	guildsGroup.Post("/:guildID/signing-secret/regenerate", signingSecretsHandler.HandleRegenerateSigningSecret)
+
 	sendMessageHandler := send_message.New(bot, stores.pg, managers.access, managers.actionParser, managers.premium)
 	app.Post("/api/send-message/channel", sessionMiddleware.SessionRequired(), helpers.WithRequestBodyValidated(sendMessageHandler.HandleSendMessageToChannel))
 	app.Post("/api/send-message/webhook", helpers.WithRequestBodyValidated(sendMessageHandler.HandleSendMessageToWebhook))
 	app.Post("/api/restore-message/channel", sessionMiddleware.SessionRequired(), helpers.WithRequestBodyValidated(sendMessageHandler.HandleRestoreMessageFromChannel))
 	app.Post("/api/restore-message/webhook", helpers.WithRequestBodyValidated(sendMessageHandler.HandleRestoreMessageFromWebhook))
 
+	actionSimulatorHandler := action_simulator.New(bot, managers.access, managers.actionParser, managers.actionHandler)
+	app.Post("/api/actions/simulate", sessionMiddleware.SessionRequired(), helpers.WithRequestBodyValidated(actionSimulatorHandler.HandleSimulateActions))
+
+	templatesHandler := templates.New(stores.pg, bot, managers.access, managers.premium)
+	app.Post("/api/templates/validate", sessionMiddleware.SessionRequired(), helpers.WithRequestBodyValidated(templatesHandler.HandleValidateTemplates))
+	app.Post("/api/templates/render", sessionMiddleware.SessionRequired(), helpers.WithRequestBodyValidated(templatesHandler.HandleRenderTemplates))
+
 	premiumHandler := premium_handler.New(stores.pg, bot, managers.access, managers.premium)
 	app.Get("/api/premium/features", sessionMiddleware.SessionRequired(), premiumHandler.HandleGetFeatures)
 	app.Get("/api/premium/entitlements", sessionMiddleware.SessionRequired(), premiumHandler.HandleListEntitlements)
@@ -0,0 +1,24 @@
+package wire
+
+import (
+	"encoding/json"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type ActionLogWire struct {
+	ID        string          `json:"id"`
+	GuildID   string          `json:"guild_id"`
+	ChannelID string          `json:"channel_id"`
+	MessageID null.String     `json:"message_id"`
+	SourceID  string          `json:"source_id"`
+	UserID    string          `json:"user_id"`
+	Actions   json.RawMessage `json:"actions"`
+	Success   bool            `json:"success"`
+	Error     null.String     `json:"error"`
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+type ActionLogListResponseWire APIResponse[[]ActionLogWire]
@@ -0,0 +1,57 @@
+package wire
+
+import (
+	"encoding/json"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gopkg.in/guregu/null.v4"
+)
+
+type ActionSetWire struct {
+	ID          string          `json:"id"`
+	GuildID     string          `json:"guild_id"`
+	Name        string          `json:"name"`
+	Description null.String     `json:"description"`
+	Actions     json.RawMessage `json:"actions"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type ActionSetListResponseWire APIResponse[[]ActionSetWire]
+
+type ActionSetGetResponseWire APIResponse[ActionSetWire]
+
+type ActionSetCreateRequestWire struct {
+	Name        string          `json:"name"`
+	Description null.String     `json:"description"`
+	Actions     json.RawMessage `json:"actions"`
+}
+
+func (req ActionSetCreateRequestWire) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&req.Description, validation.Length(0, 500)),
+		validation.Field(&req.Actions, validation.Required),
+	)
+}
+
+type ActionSetCreateResponseWire APIResponse[ActionSetWire]
+
+type ActionSetUpdateRequestWire struct {
+	Name        string          `json:"name"`
+	Description null.String     `json:"description"`
+	Actions     json.RawMessage `json:"actions"`
+}
+
+func (req ActionSetUpdateRequestWire) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&req.Description, validation.Length(0, 500)),
+		validation.Field(&req.Actions, validation.Required),
+	)
+}
+
+type ActionSetUpdateResponseWire APIResponse[ActionSetWire]
+
+type ActionSetDeleteResponseWire APIResponse[struct{}]
@@ -0,0 +1,59 @@
+package wire
+
+import (
+	"encoding/json"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gopkg.in/guregu/null.v4"
+)
+
+type ActionSimulateRequestWire struct {
+	ChannelID string              `json:"channel_id"`
+	Member    SimulatedMemberWire `json:"member"`
+	ActionSet json.RawMessage     `json:"action_set"`
+}
+
+// SimulatedMemberWire is the member that uses the component, it defaults to the current user.
+type SimulatedMemberWire struct {
+	UserID      null.String `json:"user_id"`
+	Roles       []string    `json:"roles"`
+	Permissions string      `json:"permissions"`
+	Locale      string      `json:"locale"`
+}
+
+var permissionsRegex = regexp.MustCompile(`^[0-9]*$`)
+
+func (req ActionSimulateRequestWire) Validate() error {
+	err := validation.ValidateStruct(&req,
+		validation.Field(&req.ChannelID, validation.Required),
+		validation.Field(&req.ActionSet, validation.Required),
+	)
+	if err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(&req.Member,
+		validation.Field(&req.Member.Permissions, validation.Match(permissionsRegex)),
+		validation.Field(&req.Member.Locale, validation.Length(0, 10)),
+	)
+}
+
+type ActionSimulationEventWire struct {
+	Type      string          `json:"type"`
+	ChannelID null.String     `json:"channel_id"`
+	MessageID null.String     `json:"message_id"`
+	UserID    null.String     `json:"user_id"`
+	RoleID    null.String     `json:"role_id"`
+	Key       null.String     `json:"key"`
+	Value     null.String     `json:"value"`
+	Method    null.String     `json:"method"`
+	URL       null.String     `json:"url"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type ActionSimulateResponseDataWire struct {
+	Events []ActionSimulationEventWire `json:"events"`
+}
+
+type ActionSimulateResponseWire APIResponse[ActionSimulateResponseDataWire]
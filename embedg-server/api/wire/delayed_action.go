@@ -0,0 +1,24 @@
+package wire
+
+import (
+	"encoding/json"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type DelayedActionWire struct {
+	ID        string          `json:"id"`
+	GuildID   string          `json:"guild_id"`
+	ChannelID string          `json:"channel_id"`
+	MessageID null.String     `json:"message_id"`
+	SourceID  string          `json:"source_id"`
+	UserID    string          `json:"user_id"`
+	Actions   json.RawMessage `json:"actions"`
+	ExecuteAt time.Time       `json:"execute_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type DelayedActionListResponseWire APIResponse[[]DelayedActionWire]
+
+type DelayedActionDeleteResponseWire APIResponse[struct{}]
@@ -0,0 +1,26 @@
+package wire
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type FormSubmissionFieldWire struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+type FormSubmissionWire struct {
+	ID        string                    `json:"id"`
+	GuildID   string                    `json:"guild_id"`
+	ChannelID string                    `json:"channel_id"`
+	MessageID null.String               `json:"message_id"`
+	UserID    string                    `json:"user_id"`
+	Title     string                    `json:"title"`
+	Fields    []FormSubmissionFieldWire `json:"fields"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
+
+type FormSubmissionListResponseWire APIResponse[[]FormSubmissionWire]
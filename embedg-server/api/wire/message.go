@@ -78,12 +78,14 @@ func (req MessageSendToWebhookRequestWire) Validate() error {
 }
 
 type MessageSendToChannelRequestWire struct {
-	GuildID     string                   `json:"guild_id"`
-	ChannelID   string                   `json:"channel_id"`
-	ThreadName  null.String              `json:"thread_name"`
-	MessageID   null.String              `json:"message_id"`
-	Data        json.RawMessage          `json:"data"`
-	Attachments []*MessageAttachmentWire `json:"attachments"`
+	GuildID    string      `json:"guild_id"`
+	ChannelID  string      `json:"channel_id"`
+	ThreadName null.String `json:"thread_name"`
+	MessageID  null.String `json:"message_id"`
+	// SavedMessageID is the saved message that the data belongs to, it's required to send books
+	SavedMessageID null.String              `json:"saved_message_id"`
+	Data           json.RawMessage          `json:"data"`
+	Attachments    []*MessageAttachmentWire `json:"attachments"`
 }
 
 func (req MessageSendToChannelRequestWire) Validate() error {
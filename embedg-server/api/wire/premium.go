@@ -20,6 +20,11 @@ type GetPremiumPlanFeaturesResponseDataWire struct {
 	PeriodicScheduledMessages bool `json:"periodic_scheduled_messages"`
 	MaxTemplateOps            int  `json:"max_template_ops"`
 	MaxKVKeys                 int  `json:"max_kv_keys"`
+	HTTPRequestActions        bool `json:"http_request_actions"`
+	ActionLogRetentionDays    int  `json:"action_log_retention_days"`
+	MaxActionSets             int  `json:"max_action_sets"`
+	MaxTemplateSnippetDepth   int  `json:"max_template_snippet_depth"`
+	MaxTemplateSnippetsSize   int  `json:"max_template_snippets_size"`
 }
 
 type GetPremiumPlanFeaturesResponseWire APIResponse[GetPremiumPlanFeaturesResponseDataWire]
@@ -0,0 +1,13 @@
+package wire
+
+import "time"
+
+type SigningSecretWire struct {
+	GuildID   string    `json:"guild_id"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SigningSecretGetResponseWire APIResponse[SigningSecretWire]
+
+type SigningSecretRegenerateResponseWire APIResponse[SigningSecretWire]
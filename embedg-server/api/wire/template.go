@@ -0,0 +1,92 @@
+package wire
+
+import (
+	"encoding/json"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gopkg.in/guregu/null.v4"
+)
+
+type TemplateValidateRequestWire struct {
+	GuildID string          `json:"guild_id"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (req TemplateValidateRequestWire) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.GuildID, validation.Required),
+		validation.Field(&req.Data, validation.Required),
+	)
+}
+
+// TemplateIssueWire is a problem in one of the templates of the message.
+// The line and column are 1-based and 0 if the position is unknown.
+type TemplateIssueWire struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+type TemplateValidateResponseDataWire struct {
+	Errors   []TemplateIssueWire `json:"errors"`
+	Warnings []TemplateIssueWire `json:"warnings"`
+}
+
+type TemplateValidateResponseWire APIResponse[TemplateValidateResponseDataWire]
+
+type TemplateRenderRequestWire struct {
+	ChannelID string                     `json:"channel_id"`
+	Member    SimulatedMemberWire        `json:"member"`
+	Command   *TemplateRenderCommandWire `json:"command"`
+	// KV overrides the values of keys for the preview, the stored entries aren't changed
+	KV map[string]string `json:"kv"`
+	// SavedMessageID renders a message that has been saved for the server of the channel, the data is ignored then
+	SavedMessageID null.String     `json:"saved_message_id"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// TemplateRenderCommandWire is the command that the message is rendered for, it's available as .Interaction.Command.
+type TemplateRenderCommandWire struct {
+	Name    string                            `json:"name"`
+	Options []TemplateRenderCommandOptionWire `json:"options"`
+}
+
+type TemplateRenderCommandOptionWire struct {
+	Name  string `json:"name"`
+	Type  int    `json:"type"`
+	Value string `json:"value"`
+}
+
+func (req TemplateRenderRequestWire) Validate() error {
+	err := validation.ValidateStruct(&req,
+		validation.Field(&req.ChannelID, validation.Required),
+		validation.Field(&req.Data, validation.When(!req.SavedMessageID.Valid, validation.Required)),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = validation.ValidateStruct(&req.Member,
+		validation.Field(&req.Member.Permissions, validation.Match(permissionsRegex)),
+		validation.Field(&req.Member.Locale, validation.Length(0, 10)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if req.Command != nil {
+		return validation.ValidateStruct(req.Command,
+			validation.Field(&req.Command.Name, validation.Required, validation.Length(1, 32)),
+			validation.Field(&req.Command.Options, validation.Length(0, 25)),
+		)
+	}
+
+	return nil
+}
+
+type TemplateRenderResponseDataWire struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type TemplateRenderResponseWire APIResponse[TemplateRenderResponseDataWire]
@@ -0,0 +1,59 @@
+package wire
+
+import (
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gopkg.in/guregu/null.v4"
+)
+
+var templateSnippetNameRegex = regexp.MustCompile(`^[-_a-zA-Z0-9]+$`)
+
+type TemplateSnippetWire struct {
+	ID          string      `json:"id"`
+	GuildID     string      `json:"guild_id"`
+	Name        string      `json:"name"`
+	Description null.String `json:"description"`
+	Content     string      `json:"content"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type TemplateSnippetListResponseWire APIResponse[[]TemplateSnippetWire]
+
+type TemplateSnippetGetResponseWire APIResponse[TemplateSnippetWire]
+
+type TemplateSnippetCreateRequestWire struct {
+	Name        string      `json:"name"`
+	Description null.String `json:"description"`
+	Content     string      `json:"content"`
+}
+
+func (req TemplateSnippetCreateRequestWire) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required, validation.Length(1, 64), validation.Match(templateSnippetNameRegex)),
+		validation.Field(&req.Description, validation.Length(0, 500)),
+		validation.Field(&req.Content, validation.Required),
+	)
+}
+
+type TemplateSnippetCreateResponseWire APIResponse[TemplateSnippetWire]
+
+type TemplateSnippetUpdateRequestWire struct {
+	Name        string      `json:"name"`
+	Description null.String `json:"description"`
+	Content     string      `json:"content"`
+}
+
+func (req TemplateSnippetUpdateRequestWire) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required, validation.Length(1, 64), validation.Match(templateSnippetNameRegex)),
+		validation.Field(&req.Description, validation.Length(0, 500)),
+		validation.Field(&req.Content, validation.Required),
+	)
+}
+
+type TemplateSnippetUpdateResponseWire APIResponse[TemplateSnippetWire]
+
+type TemplateSnippetDeleteResponseWire APIResponse[struct{}]
@@ -0,0 +1,16 @@
+package wire
+
+import "time"
+
+type TemporaryRoleWire struct {
+	ID        string    `json:"id"`
+	GuildID   string    `json:"guild_id"`
+	UserID    string    `json:"user_id"`
+	RoleID    string    `json:"role_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TemporaryRoleListResponseWire APIResponse[[]TemporaryRoleWire]
+
+type TemporaryRoleRevokeResponseWire APIResponse[struct{}]
@@ -28,7 +28,7 @@ func New(token string, pg *postgres.PostgresStore) (*Bot, error) {
 		return nil, err
 	}
 
-	manager.Intents = discordgo.IntentGuilds | discordgo.IntentGuildMessages | discordgo.IntentGuildEmojis
+	manager.Intents = discordgo.IntentGuilds | discordgo.IntentGuildMessages | discordgo.IntentGuildEmojis | discordgo.IntentGuildMessageReactions
 	manager.State = discordgo.NewState()
 	manager.Presence = &discordgo.GatewayStatusUpdate{
 		Game: discordgo.Activity{
@@ -51,6 +51,8 @@ func New(token string, pg *postgres.PostgresStore) (*Bot, error) {
 	b.AddHandler(b.onEvent)
 
 	b.AddHandler(b.onMessageDelete)
+	b.AddHandler(b.onMessageReactionAdd)
+	b.AddHandler(b.onMessageReactionRemove)
 
 	go b.lazyTierTask()
 
@@ -32,6 +32,86 @@ func (b *Bot) onMessageDelete(s *discordgo.Session, msg *discordgo.MessageDelete
 	if err != nil && err != sql.ErrNoRows {
 		log.Error().Err(err).Msg("Failed to delete action set for deleted message")
 	}
+
+	err = b.pg.Q.DeleteActionSetUsagesForMessage(context.TODO(), msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete action set usages for deleted message")
+	}
+
+	err = b.pg.Q.DeletePoll(context.TODO(), msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete poll for deleted message")
+	}
+
+	err = b.pg.Q.DeleteMessageReactionsForMessage(context.TODO(), msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete reactions for deleted message")
+	}
+}
+
+func (b *Bot) onMessageReactionAdd(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	if r.GuildID == "" || r.Member == nil || r.Member.User == nil || r.Member.User.Bot {
+		return
+	}
+
+	setID, ok := b.getReactionActionSetID(r.MessageReaction)
+	if !ok {
+		return
+	}
+
+	b.handleReaction(s, r.MessageReaction, setID, r.Member, true)
+}
+
+func (b *Bot) onMessageReactionRemove(s *discordgo.Session, r *discordgo.MessageReactionRemove) {
+	if r.GuildID == "" {
+		return
+	}
+
+	setID, ok := b.getReactionActionSetID(r.MessageReaction)
+	if !ok {
+		return
+	}
+
+	// The event doesn't contain the member, so we have to fetch it to know its current roles
+	member, err := s.GuildMember(r.GuildID, r.UserID)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to get member for removed reaction")
+		return
+	}
+	if member.User == nil || member.User.Bot {
+		return
+	}
+
+	b.handleReaction(s, r.MessageReaction, setID, member, false)
+}
+
+// getReactionActionSetID returns false if the reaction doesn't execute any actions, so most reactions can be ignored right away.
+func (b *Bot) getReactionActionSetID(r *discordgo.MessageReaction) (string, bool) {
+	setID, ok, err := b.ActionHandler.GetReactionActionSetID(r)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get action set of reaction")
+		return "", false
+	}
+	return setID, ok
+}
+
+func (b *Bot) handleReaction(s *discordgo.Session, r *discordgo.MessageReaction, setID string, member *discordgo.Member, added bool) {
+	member.GuildID = r.GuildID
+
+	// this is workaround to compute the permissions using discordgo, we remove it afterwards
+	b.State.MemberAdd(member)
+	perms, err := b.State.UserChannelPermissions(member.User.ID, r.ChannelID)
+	b.State.MemberRemove(member)
+	if err != nil && err != discordgo.ErrStateNotFound {
+		log.Error().Err(err).Msg("Failed to compute permissions for reaction")
+		return
+	}
+	member.Permissions = perms
+
+	err = b.ActionHandler.HandleReaction(s, r, setID, member, added)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to handle reaction")
+	}
 }
 
 func (b *Bot) onInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -55,9 +135,21 @@ func (b *Bot) onInteractionCreate(s *discordgo.Session, i *discordgo.Interaction
 		}
 	} else if i.Type == discordgo.InteractionModalSubmit {
 		data := i.ModalSubmitData()
-		err := b.handleModalInteraction(s, i.Interaction, data)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to handle modal interaction")
+		if strings.HasPrefix(data.CustomID, "action:") {
+			gi := &handler.GatewayInteraction{
+				Inner:   i.Interaction,
+				Session: s,
+			}
+
+			err := b.ActionHandler.HandleActionInteraction(s, gi)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to handle action form interaction")
+			}
+		} else {
+			err := b.handleModalInteraction(s, i.Interaction, data)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to handle modal interaction")
+			}
 		}
 	} else if i.Type == discordgo.InteractionApplicationCommand {
 		data := i.ApplicationCommandData()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
 	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
 	"github.com/merlinfuchs/embed-generator/embedg-server/util"
 	"github.com/rs/zerolog/log"
@@ -181,6 +182,27 @@ func (b *Bot) EditMessageInChannel(ctx context.Context, channelID string, messag
 	return newMessage, nil
 }
 
+// AddReactionsToMessage reacts to the message with the emojis of the reactions in order.
+// Reactions are always added by the main bot because it's the one receiving the reaction events.
+func (b *Bot) AddReactionsToMessage(ctx context.Context, channelID string, messageID string, reactions []actions.ReactionWithActions) error {
+	if len(reactions) > actions.MaxMessageReactions {
+		reactions = reactions[:actions.MaxMessageReactions]
+	}
+
+	for _, reaction := range reactions {
+		if reaction.Emoji == nil {
+			continue
+		}
+
+		err := b.Session.MessageReactionAdd(channelID, messageID, reaction.APIName(), discordgo.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("Failed to add reaction: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // FindWebhookForChannel returns a webhook for the given channel that was created by the bot or the configured custom bot.
 func (b *Bot) FindWebhookForChannel(ctx context.Context, channelID string) (*discordgo.Webhook, error) {
 	channel, err := b.State.Channel(channelID)
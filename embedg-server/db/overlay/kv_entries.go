@@ -0,0 +1,204 @@
+package overlay
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/model"
+	"github.com/merlinfuchs/embed-generator/embedg-server/store"
+)
+
+type kvKey struct {
+	guildID string
+	key     string
+}
+
+// KVEntryStore keeps all changes in memory and reads through to the inner store for keys that haven't been changed.
+// It's used to execute templates without changing the stored entries, all changes are gone with the store.
+type KVEntryStore struct {
+	sync.Mutex
+
+	inner store.KVEntryStore
+	// A nil entry means that the key has been deleted
+	entries map[kvKey]*model.KVEntry
+}
+
+func NewKVEntryStore(inner store.KVEntryStore) *KVEntryStore {
+	return &KVEntryStore{
+		inner:   inner,
+		entries: make(map[kvKey]*model.KVEntry),
+	}
+}
+
+// GetKVEntry treats entries that have expired as deleted, even if they haven't been cleaned up yet.
+func (s *KVEntryStore) GetKVEntry(ctx context.Context, guildID string, key string) (model.KVEntry, error) {
+	s.Lock()
+	entry, ok := s.entries[kvKey{guildID, key}]
+	s.Unlock()
+
+	if !ok {
+		innerEntry, err := s.inner.GetKVEntry(ctx, guildID, key)
+		if err != nil {
+			return model.KVEntry{}, err
+		}
+		entry = &innerEntry
+	}
+	if entry == nil || isExpired(*entry) {
+		return model.KVEntry{}, store.ErrNotFound
+	}
+	return *entry, nil
+}
+
+func (s *KVEntryStore) SetKVEntry(ctx context.Context, entry model.KVEntry) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.entries[kvKey{entry.GuildID, entry.Key}] = &entry
+	return nil
+}
+
+func (s *KVEntryStore) IncreaseKVEntry(ctx context.Context, params model.KVEntryIncreaseParams) (model.KVEntry, error) {
+	entry, err := s.GetKVEntry(ctx, params.GuildID, params.Key)
+	if err != nil {
+		if err != store.ErrNotFound {
+			return model.KVEntry{}, err
+		}
+
+		entry = model.KVEntry{
+			Key:       params.Key,
+			GuildID:   params.GuildID,
+			Value:     "0",
+			CreatedAt: params.CreatedAt,
+		}
+	}
+
+	value, err := strconv.Atoi(entry.Value)
+	if err != nil {
+		return model.KVEntry{}, fmt.Errorf("value of key %q is not a number", params.Key)
+	}
+
+	entry.Value = strconv.Itoa(value + params.Delta)
+	entry.ExpiresAt = params.ExpiresAt
+	entry.UpdatedAt = params.UpdatedAt
+
+	err = s.SetKVEntry(ctx, entry)
+	if err != nil {
+		return model.KVEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (s *KVEntryStore) DeleteKVEntry(ctx context.Context, guildID string, key string) (model.KVEntry, error) {
+	entry, err := s.GetKVEntry(ctx, guildID, key)
+	if err != nil {
+		return model.KVEntry{}, err
+	}
+
+	s.Lock()
+	s.entries[kvKey{guildID, key}] = nil
+	s.Unlock()
+
+	return entry, nil
+}
+
+func (s *KVEntryStore) SearchKVEntries(ctx context.Context, guildID string, pattern string) ([]model.KVEntry, error) {
+	innerEntries, err := s.inner.SearchKVEntries(ctx, guildID, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := likePatternToRegex(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	res := make([]model.KVEntry, 0, len(innerEntries))
+	for _, entry := range innerEntries {
+		if _, ok := s.entries[kvKey{guildID, entry.Key}]; !ok && !isExpired(entry) {
+			res = append(res, entry)
+		}
+	}
+
+	for key, entry := range s.entries {
+		if key.guildID == guildID && entry != nil && !isExpired(*entry) && re.MatchString(key.key) {
+			res = append(res, *entry)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+
+	return res, nil
+}
+
+func (s *KVEntryStore) CountKVEntries(ctx context.Context, guildID string) (int, error) {
+	count, err := s.inner.CountKVEntries(ctx, guildID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	for key, entry := range s.entries {
+		if key.guildID != guildID {
+			continue
+		}
+
+		_, err := s.inner.GetKVEntry(ctx, guildID, key.key)
+		if err != nil && err != store.ErrNotFound {
+			return 0, err
+		}
+		existsInner := err == nil
+		exists := entry != nil && !isExpired(*entry)
+
+		if exists && !existsInner {
+			count++
+		} else if !exists && existsInner {
+			count--
+		}
+	}
+
+	return count, nil
+}
+
+func isExpired(entry model.KVEntry) bool {
+	return entry.ExpiresAt.Valid && !entry.ExpiresAt.Time.After(time.Now())
+}
+
+// likePatternToRegex converts a pattern of the SQL LIKE operator to a regular expression.
+func likePatternToRegex(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString("(?s:.*)")
+		case r == '_':
+			b.WriteString("(?s:.)")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS form_submissions (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    message_id TEXT, -- This is null if the form was opened by a custom command
+    user_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    fields JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ON form_submissions (guild_id, created_at);
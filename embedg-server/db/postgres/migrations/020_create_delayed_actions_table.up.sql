@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS delayed_actions (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    message_id TEXT, -- This is null if the actions belong to a custom command
+    source_id TEXT NOT NULL, -- The action set id or the custom command id
+    user_id TEXT NOT NULL,
+    actions JSONB NOT NULL,
+    derived_permissions JSONB,
+    interaction JSONB NOT NULL,
+    execute_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ON delayed_actions (execute_at);
+CREATE INDEX ON delayed_actions (guild_id);
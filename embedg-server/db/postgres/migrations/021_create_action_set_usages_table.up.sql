@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS action_set_usages (
+    message_id TEXT NOT NULL, -- This is empty for custom commands
+    set_id TEXT NOT NULL, -- This is the custom command id for custom commands
+    user_id TEXT NOT NULL,
+    uses INTEGER NOT NULL,
+    last_used_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (message_id, set_id, user_id)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS guild_signing_secrets (
+    guild_id TEXT PRIMARY KEY,
+    secret TEXT NOT NULL, -- Used to sign the payloads of http request actions
+    created_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS action_logs (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    message_id TEXT, -- This is null if the actions belong to a custom command
+    source_id TEXT NOT NULL, -- The action set id or the custom command id
+    user_id TEXT NOT NULL,
+    actions JSONB NOT NULL, -- The types of the actions that have been executed
+    success BOOLEAN NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL -- Depends on the plan of the guild at the time of the execution
+);
+
+CREATE INDEX ON action_logs (guild_id, created_at);
+CREATE INDEX ON action_logs (expires_at);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS temporary_roles (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    role_id TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (guild_id, user_id, role_id)
+);
+
+CREATE INDEX ON temporary_roles (expires_at);
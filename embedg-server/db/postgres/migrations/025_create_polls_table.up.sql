@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS polls (
+    message_id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    results_message_id TEXT, -- The saved message that is sent when the poll is closed
+    closes_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ON polls (closes_at);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    message_id TEXT NOT NULL REFERENCES polls (message_id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    option TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (message_id, user_id)
+);
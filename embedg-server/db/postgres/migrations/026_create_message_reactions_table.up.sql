@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS message_reactions (
+    message_id TEXT NOT NULL,
+    emoji TEXT NOT NULL, -- The id of custom emojis or the unicode emoji itself
+    set_id TEXT NOT NULL,
+    PRIMARY KEY (message_id, emoji)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS guild_action_sets (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    actions JSONB NOT NULL,
+    derived_permissions JSONB NOT NULL, -- The permissions of the user that has last saved the action set
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ON guild_action_sets (guild_id);
@@ -12,6 +12,28 @@ import (
 	"github.com/sqlc-dev/pqtype"
 )
 
+type ActionLog struct {
+	ID        string
+	GuildID   string
+	ChannelID string
+	MessageID sql.NullString
+	SourceID  string
+	UserID    string
+	Actions   json.RawMessage
+	Success   bool
+	Error     sql.NullString
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type ActionSetUsage struct {
+	MessageID  string
+	SetID      string
+	UserID     string
+	Uses       int32
+	LastUsedAt time.Time
+}
+
 type CustomBot struct {
 	ID                      string
 	GuildID                 string
@@ -47,6 +69,20 @@ type CustomCommand struct {
 	LastUsedAt         time.Time
 }
 
+type DelayedAction struct {
+	ID                 string
+	GuildID            string
+	ChannelID          string
+	MessageID          sql.NullString
+	SourceID           string
+	UserID             string
+	Actions            json.RawMessage
+	DerivedPermissions pqtype.NullRawMessage
+	Interaction        json.RawMessage
+	ExecuteAt          time.Time
+	CreatedAt          time.Time
+}
+
 type EmbedLink struct {
 	ID             string
 	Url            string
@@ -78,6 +114,44 @@ type Entitlement struct {
 	ConsumedGuildID sql.NullString
 }
 
+type FormSubmission struct {
+	ID        string
+	GuildID   string
+	ChannelID string
+	MessageID sql.NullString
+	UserID    string
+	Title     string
+	Fields    json.RawMessage
+	CreatedAt time.Time
+}
+
+type GuildActionSet struct {
+	ID                 string
+	GuildID            string
+	Name               string
+	Description        sql.NullString
+	Actions            json.RawMessage
+	DerivedPermissions json.RawMessage
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type GuildSigningSecret struct {
+	GuildID   string
+	Secret    string
+	CreatedAt time.Time
+}
+
+type GuildTemplateSnippet struct {
+	ID          string
+	GuildID     string
+	Name        string
+	Description sql.NullString
+	Content     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
 type Image struct {
 	ID              string
 	UserID          string
@@ -108,6 +182,30 @@ type MessageActionSet struct {
 	Ephemeral          bool
 }
 
+type MessageReaction struct {
+	MessageID string
+	Emoji     string
+	SetID     string
+}
+
+type Poll struct {
+	MessageID        string
+	GuildID          string
+	ChannelID        string
+	ResultsMessageID sql.NullString
+	ClosesAt         sql.NullTime
+	ClosedAt         sql.NullTime
+	CreatedAt        time.Time
+}
+
+type PollVote struct {
+	MessageID string
+	UserID    string
+	Option    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type SavedMessage struct {
 	ID          string
 	CreatorID   string
@@ -155,6 +253,15 @@ type SharedMessage struct {
 	Data      json.RawMessage
 }
 
+type TemporaryRole struct {
+	ID        string
+	GuildID   string
+	UserID    string
+	RoleID    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
 type User struct {
 	ID            string
 	Name          string
@@ -0,0 +1,18 @@
+package postgres
+
+import "context"
+
+// GetPollVoteCounts returns the number of votes for each option of the poll.
+func (s *PostgresStore) GetPollVoteCounts(ctx context.Context, messageID string) (map[string]int, error) {
+	rows, err := s.Q.CountPollVotes(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]int, len(rows))
+	for _, row := range rows {
+		res[row.Option] = int(row.Votes)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,8 @@
+-- name: InsertActionLog :one
+INSERT INTO action_logs (id, guild_id, channel_id, message_id, source_id, user_id, actions, success, error, created_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
+
+-- name: GetActionLogs :many
+SELECT * FROM action_logs WHERE guild_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3;
+
+-- name: DeleteExpiredActionLogs :execrows
+DELETE FROM action_logs WHERE expires_at <= $1;
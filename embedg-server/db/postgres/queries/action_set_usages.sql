@@ -0,0 +1,30 @@
+-- name: GetActionSetUsage :one
+SELECT * FROM action_set_usages WHERE message_id = $1 AND set_id = $2 AND user_id = $3;
+
+-- name: LockActionSetUsages :exec
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg(message_id)::TEXT || ':' || sqlc.arg(set_id)::TEXT));
+
+-- name: CountActionSetUses :one
+SELECT COALESCE(SUM(uses), 0)::BIGINT AS total_uses FROM action_set_usages WHERE message_id = $1 AND set_id = $2;
+
+-- name: IncreaseActionSetUsage :one
+INSERT INTO action_set_usages (
+    message_id, 
+    set_id, 
+    user_id, 
+    uses, 
+    last_used_at
+) VALUES (
+    $1, 
+    $2, 
+    $3, 
+    1, 
+    $4
+) ON CONFLICT (message_id, set_id, user_id) 
+DO UPDATE SET 
+    uses = action_set_usages.uses + 1, 
+    last_used_at = EXCLUDED.last_used_at
+RETURNING *;
+
+-- name: DeleteActionSetUsagesForMessage :exec
+DELETE FROM action_set_usages WHERE message_id = $1;
@@ -0,0 +1,12 @@
+-- name: InsertDelayedAction :one
+INSERT INTO delayed_actions (id, guild_id, channel_id, message_id, source_id, user_id, actions, derived_permissions, interaction, execute_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
+
+-- name: GetDueDelayedActions :many
+SELECT * FROM delayed_actions WHERE execute_at <= $1 ORDER BY execute_at ASC LIMIT $2;
+
+-- name: GetDelayedActions :many
+SELECT * FROM delayed_actions WHERE guild_id = $1 ORDER BY execute_at ASC;
+
+-- name: DeleteDelayedAction :one
+DELETE FROM delayed_actions WHERE id = $1 AND guild_id = $2 RETURNING *;
+
@@ -0,0 +1,8 @@
+-- name: InsertFormSubmission :one
+INSERT INTO form_submissions (id, guild_id, channel_id, message_id, user_id, title, fields, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
+
+-- name: GetFormSubmissions :many
+SELECT * FROM form_submissions WHERE guild_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3;
+
+-- name: GetAllFormSubmissions :many
+SELECT * FROM form_submissions WHERE guild_id = $1 ORDER BY created_at ASC;
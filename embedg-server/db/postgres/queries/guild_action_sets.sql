@@ -0,0 +1,17 @@
+-- name: GetGuildActionSets :many
+SELECT * FROM guild_action_sets WHERE guild_id = $1 ORDER BY name ASC;
+
+-- name: GetGuildActionSet :one
+SELECT * FROM guild_action_sets WHERE id = $1 AND guild_id = $2;
+
+-- name: CountGuildActionSets :one
+SELECT COUNT(*) FROM guild_action_sets WHERE guild_id = $1;
+
+-- name: InsertGuildActionSet :one
+INSERT INTO guild_action_sets (id, guild_id, name, description, actions, derived_permissions, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
+
+-- name: UpdateGuildActionSet :one
+UPDATE guild_action_sets SET name = $3, description = $4, actions = $5, derived_permissions = $6, updated_at = $7 WHERE id = $1 AND guild_id = $2 RETURNING *;
+
+-- name: DeleteGuildActionSet :one
+DELETE FROM guild_action_sets WHERE id = $1 AND guild_id = $2 RETURNING *;
@@ -0,0 +1,20 @@
+-- name: GetGuildSigningSecret :one
+SELECT * FROM guild_signing_secrets WHERE guild_id = $1;
+
+-- name: InsertGuildSigningSecret :exec
+INSERT INTO guild_signing_secrets (guild_id, secret, created_at) VALUES ($1, $2, $3) ON CONFLICT (guild_id) DO NOTHING;
+
+-- name: UpsertGuildSigningSecret :one
+INSERT INTO guild_signing_secrets (
+    guild_id, 
+    secret, 
+    created_at
+) VALUES (
+    $1, 
+    $2, 
+    $3
+) ON CONFLICT (guild_id) 
+DO UPDATE SET 
+    secret = EXCLUDED.secret, 
+    created_at = EXCLUDED.created_at
+RETURNING *;
@@ -0,0 +1,14 @@
+-- name: GetGuildTemplateSnippets :many
+SELECT * FROM guild_template_snippets WHERE guild_id = $1 ORDER BY name ASC;
+
+-- name: GetGuildTemplateSnippet :one
+SELECT * FROM guild_template_snippets WHERE id = $1 AND guild_id = $2;
+
+-- name: InsertGuildTemplateSnippet :one
+INSERT INTO guild_template_snippets (id, guild_id, name, description, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: UpdateGuildTemplateSnippet :one
+UPDATE guild_template_snippets SET name = $3, description = $4, content = $5, updated_at = $6 WHERE id = $1 AND guild_id = $2 RETURNING *;
+
+-- name: DeleteGuildTemplateSnippet :one
+DELETE FROM guild_template_snippets WHERE id = $1 AND guild_id = $2 RETURNING *;
@@ -0,0 +1,8 @@
+-- name: InsertMessageReaction :exec
+INSERT INTO message_reactions (message_id, emoji, set_id) VALUES ($1, $2, $3) ON CONFLICT (message_id, emoji) DO NOTHING;
+
+-- name: GetMessageReaction :one
+SELECT * FROM message_reactions WHERE message_id = $1 AND emoji = $2;
+
+-- name: DeleteMessageReactionsForMessage :exec
+DELETE FROM message_reactions WHERE message_id = $1;
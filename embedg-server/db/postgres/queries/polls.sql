@@ -0,0 +1,39 @@
+-- name: InsertPoll :exec
+INSERT INTO polls (message_id, guild_id, channel_id, results_message_id, closes_at, created_at) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (message_id) DO NOTHING;
+
+-- name: GetPoll :one
+SELECT * FROM polls WHERE message_id = $1;
+
+-- name: GetDuePolls :many
+SELECT * FROM polls WHERE closed_at IS NULL AND closes_at <= $1 ORDER BY closes_at ASC;
+
+-- name: ClosePoll :one
+UPDATE polls SET closed_at = $2 WHERE message_id = $1 AND closed_at IS NULL RETURNING *;
+
+-- name: ReopenPoll :exec
+UPDATE polls SET closed_at = NULL WHERE message_id = $1;
+
+-- name: DeletePoll :exec
+DELETE FROM polls WHERE message_id = $1;
+
+-- name: UpsertPollVote :one
+INSERT INTO poll_votes (
+    message_id, 
+    user_id, 
+    option, 
+    created_at, 
+    updated_at
+) VALUES (
+    $1, 
+    $2, 
+    $3, 
+    $4, 
+    $5
+) ON CONFLICT (message_id, user_id) 
+DO UPDATE SET 
+    option = EXCLUDED.option, 
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: CountPollVotes :many
+SELECT option, COUNT(*) AS votes FROM poll_votes WHERE message_id = $1 GROUP BY option;
@@ -0,0 +1,31 @@
+-- name: UpsertTemporaryRole :one
+INSERT INTO temporary_roles (
+    id, 
+    guild_id, 
+    user_id, 
+    role_id, 
+    expires_at, 
+    created_at
+) VALUES (
+    $1, 
+    $2, 
+    $3, 
+    $4, 
+    $5, 
+    $6
+) ON CONFLICT (guild_id, user_id, role_id) 
+DO UPDATE SET 
+    expires_at = EXCLUDED.expires_at
+RETURNING *;
+
+-- name: GetTemporaryRoles :many
+SELECT * FROM temporary_roles WHERE guild_id = $1 ORDER BY expires_at ASC;
+
+-- name: GetExpiredTemporaryRoles :many
+SELECT * FROM temporary_roles WHERE expires_at <= $1 ORDER BY expires_at ASC LIMIT $2;
+
+-- name: DeleteTemporaryRole :one
+DELETE FROM temporary_roles WHERE id = $1 AND guild_id = $2 RETURNING *;
+
+-- name: DeleteTemporaryRoleForMember :exec
+DELETE FROM temporary_roles WHERE guild_id = $1 AND user_id = $2 AND role_id = $3;
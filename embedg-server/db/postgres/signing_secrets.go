@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+)
+
+// GetOrCreateGuildSigningSecret returns the secret that is used to sign outgoing requests for the guild.
+// A new secret is generated when the guild doesn't have one yet.
+func (s *PostgresStore) GetOrCreateGuildSigningSecret(ctx context.Context, guildID string) (pgmodel.GuildSigningSecret, error) {
+	row, err := s.Q.GetGuildSigningSecret(ctx, guildID)
+	if err != sql.ErrNoRows {
+		return row, err
+	}
+
+	secret, err := generateSigningSecret()
+	if err != nil {
+		return row, err
+	}
+
+	// Another request might have created the secret in the meantime, so we don't overwrite it here
+	err = s.Q.InsertGuildSigningSecret(ctx, pgmodel.InsertGuildSigningSecretParams{
+		GuildID:   guildID,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return row, err
+	}
+
+	return s.Q.GetGuildSigningSecret(ctx, guildID)
+}
+
+// RegenerateGuildSigningSecret replaces the secret of the guild with a new one.
+func (s *PostgresStore) RegenerateGuildSigningSecret(ctx context.Context, guildID string) (pgmodel.GuildSigningSecret, error) {
+	secret, err := generateSigningSecret()
+	if err != nil {
+		return pgmodel.GuildSigningSecret{}, err
+	}
+
+	return s.Q.UpsertGuildSigningSecret(ctx, pgmodel.UpsertGuildSigningSecretParams{
+		GuildID:   guildID,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+func generateSigningSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -27,6 +28,8 @@ func BuildConnectionDSN() string {
 
 type PostgresStore struct {
 	db *sqlx.DB
+	// tx is only set for stores that have been created by BeginTx
+	tx *sqlx.Tx
 	Q  *pgmodel.Queries
 }
 
@@ -45,3 +48,38 @@ func NewPostgresStore() *PostgresStore {
 		Q:  pgmodel.New(db),
 	}
 }
+
+// BeginTx returns a store that runs all queries inside of a new transaction.
+// The caller is responsible for committing or rolling back the transaction.
+func (s *PostgresStore) BeginTx(ctx context.Context) (*PostgresStore, *sqlx.Tx, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+
+	return &PostgresStore{
+		db: s.db,
+		tx: tx,
+		Q:  pgmodel.New(tx),
+	}, tx, nil
+}
+
+// WithTx runs fn inside of a transaction that is committed if fn doesn't return an error.
+// Stores that already belong to a transaction run fn inside of that transaction instead.
+func (s *PostgresStore) WithTx(ctx context.Context, fn func(pg *PostgresStore) error) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	pg, tx, err := s.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(pg); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
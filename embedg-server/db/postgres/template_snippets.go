@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/merlinfuchs/embed-generator/embedg-server/model"
+	"gopkg.in/guregu/null.v4"
+)
+
+func (s *PostgresStore) GetTemplateSnippets(ctx context.Context, guildID string) ([]model.TemplateSnippet, error) {
+	rows, err := s.Q.GetGuildTemplateSnippets(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	snippets := make([]model.TemplateSnippet, len(rows))
+	for i, row := range rows {
+		snippets[i] = rowToTemplateSnippet(row)
+	}
+
+	return snippets, nil
+}
+
+func rowToTemplateSnippet(row pgmodel.GuildTemplateSnippet) model.TemplateSnippet {
+	return model.TemplateSnippet{
+		ID:          row.ID,
+		GuildID:     row.GuildID,
+		Name:        row.Name,
+		Description: null.NewString(row.Description.String, row.Description.Valid),
+		Content:     row.Content,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
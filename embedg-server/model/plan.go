@@ -21,6 +21,11 @@ type PlanFeatures struct {
 	PeriodicScheduledMessages bool `mapstructure:"periodic_scheduled_messages"`
 	MaxTemplateOps            int  `mapstructure:"max_template_ops"`
 	MaxKVKeys                 int  `mapstructure:"max_kv_keys"`
+	HTTPRequestActions        bool `mapstructure:"http_request_actions"`
+	ActionLogRetentionDays    int  `mapstructure:"action_log_retention_days"`
+	MaxActionSets             int  `mapstructure:"max_action_sets"`
+	MaxTemplateSnippetDepth   int  `mapstructure:"max_template_snippet_depth"`
+	MaxTemplateSnippetsSize   int  `mapstructure:"max_template_snippets_size"`
 }
 
 func (f *PlanFeatures) Merge(b PlanFeatures) {
@@ -45,10 +50,23 @@ func (f *PlanFeatures) Merge(b PlanFeatures) {
 	if b.MaxKVKeys > f.MaxKVKeys {
 		f.MaxKVKeys = b.MaxKVKeys
 	}
+	if b.ActionLogRetentionDays > f.ActionLogRetentionDays {
+		f.ActionLogRetentionDays = b.ActionLogRetentionDays
+	}
+	if b.MaxActionSets > f.MaxActionSets {
+		f.MaxActionSets = b.MaxActionSets
+	}
+	if b.MaxTemplateSnippetDepth > f.MaxTemplateSnippetDepth {
+		f.MaxTemplateSnippetDepth = b.MaxTemplateSnippetDepth
+	}
+	if b.MaxTemplateSnippetsSize > f.MaxTemplateSnippetsSize {
+		f.MaxTemplateSnippetsSize = b.MaxTemplateSnippetsSize
+	}
 
 	f.AdvancedActionTypes = f.AdvancedActionTypes || b.AdvancedActionTypes
 	f.AIAssistant = f.AIAssistant || b.AIAssistant
 	f.IsPremium = f.IsPremium || b.IsPremium
 	f.CustomBot = f.CustomBot || b.CustomBot
 	f.PeriodicScheduledMessages = f.PeriodicScheduledMessages || b.PeriodicScheduledMessages
+	f.HTTPRequestActions = f.HTTPRequestActions || b.HTTPRequestActions
 }
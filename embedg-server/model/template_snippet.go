@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type TemplateSnippet struct {
+	ID          string
+	GuildID     string
+	Name        string
+	Description null.String
+	Content     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
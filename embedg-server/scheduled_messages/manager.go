@@ -40,6 +40,7 @@ func NewScheduledMessageManager(
 	}
 
 	go m.lazySendScheduledMessagesTask()
+	go m.lazyClosePollsTask()
 
 	return m
 }
@@ -123,7 +124,9 @@ func (m *ScheduledMessageManager) SendScheduledMessage(ctx context.Context, sche
 		"SCHEDULED_MESSAGE", features.MaxTemplateOps,
 		template.NewGuildProvider(m.bot.State, scheduledMessage.GuildID, nil),
 		template.NewChannelProvider(m.bot.State, scheduledMessage.ChannelID, nil),
+		template.NewStateProvider(m.bot.State, scheduledMessage.GuildID),
 		template.NewKVProvider(scheduledMessage.GuildID, m.pg, features.MaxKVKeys),
+		template.NewSnippetProvider(scheduledMessage.GuildID, m.pg, features.MaxTemplateSnippetDepth, features.MaxTemplateSnippetsSize),
 	)
 
 	data := &actions.MessageWithActions{}
@@ -132,6 +135,11 @@ func (m *ScheduledMessageManager) SendScheduledMessage(ctx context.Context, sche
 		return err
 	}
 
+	data, err = m.bot.ActionHandler.OpenBook(ctx, scheduledMessage.GuildID, savedMsg.ID, data)
+	if err != nil {
+		return fmt.Errorf("Failed to open book: %w", err)
+	}
+
 	if err := templates.ParseAndExecuteMessage(data); err != nil {
 		return fmt.Errorf("Failed to parse and execute message template: %w", err)
 	}
@@ -151,6 +159,11 @@ func (m *ScheduledMessageManager) SendScheduledMessage(ctx context.Context, sche
 		return helpers.BadRequest("invalid_actions", err.Error())
 	}
 
+	err = m.actionParser.CheckLibraryActionSets(data.Actions, scheduledMessage.CreatorID, scheduledMessage.GuildID, scheduledMessage.ChannelID)
+	if err != nil {
+		return helpers.BadRequest("invalid_actions", err.Error())
+	}
+
 	msg, err := m.bot.SendMessageToChannel(ctx, scheduledMessage.ChannelID, params)
 	if err != nil {
 		return fmt.Errorf("Failed to send message: %w", err)
@@ -167,5 +180,22 @@ func (m *ScheduledMessageManager) SendScheduledMessage(ctx context.Context, sche
 		return err
 	}
 
+	err = m.actionParser.CreatePollForMessage(ctx, data.Actions, scheduledMessage.GuildID, msg.ChannelID, msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create poll for message")
+		return err
+	}
+
+	err = m.actionParser.CreateReactionsForMessage(ctx, data.Reactions, msg.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create reactions for message")
+		return err
+	}
+
+	err = m.bot.AddReactionsToMessage(ctx, msg.ChannelID, msg.ID, data.Reactions)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to add reactions to message")
+	}
+
 	return nil
 }
@@ -0,0 +1,198 @@
+package scheduled_messages
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/merlinfuchs/discordgo"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions"
+	"github.com/merlinfuchs/embed-generator/embedg-server/actions/template"
+	"github.com/merlinfuchs/embed-generator/embedg-server/db/postgres/pgmodel"
+	"github.com/rs/zerolog/log"
+)
+
+func (m *ScheduledMessageManager) lazyClosePollsTask() {
+	for {
+		time.Sleep(10 * time.Second)
+
+		polls, err := m.pg.Q.GetDuePolls(context.Background(), sql.NullTime{Time: time.Now().UTC(), Valid: true})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to retrieve due polls")
+			continue
+		}
+
+		for _, poll := range polls {
+			err := m.ClosePoll(context.Background(), poll)
+			if err != nil {
+				log.Error().Err(err).Str("guild_id", poll.GuildID).Msg("Failed to close poll")
+			}
+		}
+	}
+}
+
+// ClosePoll stops accepting votes for the poll and posts the final results to the channel of the poll.
+// The poll is opened again if the results can't be posted, so closing it is retried later.
+func (m *ScheduledMessageManager) ClosePoll(ctx context.Context, poll pgmodel.Poll) error {
+	poll, err := m.pg.Q.ClosePoll(ctx, pgmodel.ClosePollParams{
+		MessageID: poll.MessageID,
+		ClosedAt:  sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		// The poll has already been closed
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	err = m.sendPollResults(ctx, poll)
+	if err != nil {
+		if err := m.pg.Q.ReopenPoll(ctx, poll.MessageID); err != nil {
+			log.Error().Err(err).Msg("Failed to reopen poll")
+		}
+		return err
+	}
+
+	err = m.disablePollComponents(ctx, poll)
+	if err != nil {
+		log.Error().Err(err).Str("guild_id", poll.GuildID).Msg("Failed to disable components of closed poll")
+	}
+
+	return nil
+}
+
+// sendPollResults posts the final results of the poll, either formatted as text or with the results message of the poll.
+func (m *ScheduledMessageManager) sendPollResults(ctx context.Context, poll pgmodel.Poll) error {
+	votes, err := m.pg.GetPollVoteCounts(ctx, poll.MessageID)
+	if err != nil {
+		return fmt.Errorf("Failed to count poll votes: %w", err)
+	}
+
+	pollData := template.NewPollData(votes, "", true, poll.ClosesAt.Time)
+
+	params := &discordgo.WebhookParams{
+		Content: formatPollResults(pollData),
+	}
+
+	if poll.ResultsMessageID.Valid {
+		savedMsg, err := m.pg.Q.GetSavedMessageForGuild(ctx, pgmodel.GetSavedMessageForGuildParams{
+			ID:      poll.ResultsMessageID.String,
+			GuildID: sql.NullString{String: poll.GuildID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to get results message of poll: %w", err)
+		}
+
+		features, err := m.planStore.GetPlanFeaturesForGuild(ctx, poll.GuildID)
+		if err != nil {
+			return fmt.Errorf("could not get plan features: %w", err)
+		}
+
+		templates := template.NewContext(
+			"POLL_RESULTS", features.MaxTemplateOps,
+			template.NewGuildProvider(m.bot.State, poll.GuildID, nil),
+			template.NewChannelProvider(m.bot.State, poll.ChannelID, nil),
+			template.NewStateProvider(m.bot.State, poll.GuildID),
+			template.NewKVProvider(poll.GuildID, m.pg, features.MaxKVKeys),
+			template.NewSnippetProvider(poll.GuildID, m.pg, features.MaxTemplateSnippetDepth, features.MaxTemplateSnippetsSize),
+		)
+		templates.Set("Poll", pollData)
+
+		data := &actions.MessageWithActions{}
+		err = json.Unmarshal(savedMsg.Data, data)
+		if err != nil {
+			return err
+		}
+
+		if err := templates.ParseAndExecuteMessage(data); err != nil {
+			return fmt.Errorf("Failed to parse and execute message template: %w", err)
+		}
+
+		// There is no permission context for the results, so the components don't have any actions
+		params = &discordgo.WebhookParams{
+			Content:         data.Content,
+			Username:        data.Username,
+			AvatarURL:       data.AvatarURL,
+			TTS:             data.TTS,
+			Embeds:          data.Embeds,
+			AllowedMentions: data.AllowedMentions,
+		}
+	}
+
+	_, err = m.bot.SendMessageToChannel(ctx, poll.ChannelID, params)
+	if err != nil {
+		return fmt.Errorf("Failed to send poll results: %w", err)
+	}
+
+	return nil
+}
+
+// disablePollComponents disables the buttons and select menus of the poll message, so it's visible that no more votes are accepted.
+func (m *ScheduledMessageManager) disablePollComponents(ctx context.Context, poll pgmodel.Poll) error {
+	msg, err := m.bot.Session.ChannelMessage(poll.ChannelID, poll.MessageID)
+	if err != nil {
+		return fmt.Errorf("Failed to get poll message: %w", err)
+	}
+
+	if len(msg.Components) == 0 {
+		return nil
+	}
+
+	for _, component := range msg.Components {
+		row, ok := component.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+
+		for _, c := range row.Components {
+			switch c := c.(type) {
+			case *discordgo.Button:
+				// Link buttons don't vote, so they stay usable
+				if c.Style != discordgo.LinkButton {
+					c.Disabled = true
+				}
+			case *discordgo.SelectMenu:
+				c.Disabled = true
+			}
+		}
+	}
+
+	_, err = m.bot.EditMessageInChannel(ctx, poll.ChannelID, poll.MessageID, &discordgo.WebhookEdit{
+		Components: &msg.Components,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to edit poll message: %w", err)
+	}
+
+	return nil
+}
+
+func formatPollResults(poll *template.PollData) string {
+	results := poll.Results()
+
+	options := make([]string, 0, len(results))
+	for option := range results {
+		options = append(options, option)
+	}
+	slices.SortFunc(options, func(a, b string) int {
+		if results[a] != results[b] {
+			return results[b] - results[a]
+		}
+		return strings.Compare(a, b)
+	})
+
+	lines := []string{"**The poll has been closed!**"}
+	for _, option := range options {
+		lines = append(lines, fmt.Sprintf("%s: %d votes (%d%%)", option, results[option], poll.Percent(option)))
+	}
+	if len(options) == 0 {
+		lines = append(lines, "Nobody has voted.")
+	}
+
+	return strings.Join(lines, "\n")
+}
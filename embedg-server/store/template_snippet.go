@@ -0,0 +1,11 @@
+package store
+
+import (
+	"context"
+
+	"github.com/merlinfuchs/embed-generator/embedg-server/model"
+)
+
+type TemplateSnippetStore interface {
+	GetTemplateSnippets(ctx context.Context, guildID string) ([]model.TemplateSnippet, error)
+}